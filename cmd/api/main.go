@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -22,6 +23,7 @@ import (
 	"github.com/sefazor/ourphotos-backend/pkg/email"
 	"github.com/sefazor/ourphotos-backend/pkg/payment"
 	"github.com/sefazor/ourphotos-backend/pkg/qrcode"
+	"github.com/sefazor/ourphotos-backend/pkg/realtime"
 	"github.com/sefazor/ourphotos-backend/pkg/storage"
 	"github.com/sefazor/ourphotos-backend/pkg/utils"
 )
@@ -45,6 +47,22 @@ func main() {
 		&models.Photos{},
 		&models.CreditPackage{},
 		&models.UserCreditPurchase{},
+		&models.PhotoReaction{},
+		&models.PhotoComment{},
+		&models.PhotoReport{},
+		&models.PhotoAlbum{},
+		&models.EventURLHistory{},
+		&models.EventPurgeLog{},
+		&models.EventExtensionPackage{},
+		&models.EventExtensionPurchase{},
+		&models.EventTemplate{},
+		&models.EventPasswordAttempt{},
+		&models.EventAccessCode{},
+		&models.GuestbookEntry{},
+		&models.EventGuest{},
+		&models.EventHit{},
+		&models.EventStatsBucket{},
+		&models.EventTransfer{},
 	); err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
@@ -55,6 +73,29 @@ func main() {
 	photoRepo := repository.NewPhotoRepository(db)
 	packageRepo := repository.NewCreditPackageRepository(db)
 	purchaseRepo := repository.NewUserCreditPurchaseRepository(db)
+	reactionRepo := repository.NewReactionRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	guestbookRepo := repository.NewGuestbookRepository(db)
+	guestRepo := repository.NewEventGuestRepository(db)
+	reportRepo := repository.NewReportRepository(db)
+	albumRepo := repository.NewAlbumRepository(db)
+	purgeLogRepo := repository.NewPurgeLogRepository(db)
+	extensionRepo := repository.NewEventExtensionRepository(db)
+	templateRepo := repository.NewEventTemplateRepository(db)
+	attemptRepo := repository.NewPasswordAttemptRepository(db)
+	accessCodeRepo := repository.NewAccessCodeRepository(db)
+	analyticsRepo := repository.NewAnalyticsRepository(db)
+	transferRepo := repository.NewEventTransferRepository(db)
+
+	// Full-text arama indeksi
+	if err := photoRepo.EnsureSearchIndex(); err != nil {
+		log.Printf("Warning: failed to create photo search index: %v\n", err)
+	}
+
+	// Etkinlik listesi sıralama ve filtre indeksleri
+	if err := eventRepo.EnsureListIndexes(); err != nil {
+		log.Printf("Warning: failed to create event list indexes: %v\n", err)
+	}
 
 	// Storage services
 	imgStorage := storage.NewCloudflareImages(
@@ -66,6 +107,12 @@ func main() {
 	// Email service
 	emailService := email.NewEmailService()
 
+	// Canlı fotoğraf akışı: process içi pub/sub, Postgres LISTEN/NOTIFY ile tüm sunuculara dağıtılır
+	photoFeed := realtime.NewBroker()
+	photoFanout := realtime.NewPostgresFanout(db, os.Getenv("DATABASE_URL"), "photo_feed")
+	photoFeed.SetNotifier(photoFanout)
+	go photoFanout.Listen(context.Background(), photoFeed.Dispatch)
+
 	// Services
 	authService := service.NewAuthService(userRepo, emailService)
 	userService := service.NewUserService(userRepo, emailService)
@@ -74,12 +121,45 @@ func main() {
 		eventRepo,
 		imgStorage,
 		userRepo,
+		reactionRepo,
+		commentRepo,
+		albumRepo,
+		photoFeed,
+	)
+	reactionService := service.NewReactionService(reactionRepo, photoRepo)
+	commentService := service.NewCommentService(commentRepo, photoRepo, eventRepo, userRepo)
+	guestbookService := service.NewGuestbookService(guestbookRepo, photoRepo, eventRepo, userRepo)
+	guestListService := service.NewGuestListService(guestRepo, eventRepo, userRepo, accessCodeRepo, emailService)
+	albumService := service.NewAlbumService(albumRepo, photoRepo, eventRepo)
+	analyticsService := service.NewAnalyticsService(analyticsRepo, eventRepo, photoRepo, userRepo)
+	transferService := service.NewEventTransferService(transferRepo, eventRepo, userRepo, emailService)
+	reportService := service.NewReportService(
+		reportRepo,
+		photoRepo,
+		eventRepo,
+		userRepo,
+		emailService,
+		imgStorage,
+		cfg.Moderation.ReportHideThreshold,
 	)
 
 	// QR Code Service
-	qrService := qrcode.NewQRService("https://ourphotos.co/e/")
+	qrService := qrcode.NewQRService(cfg.QR.BaseURL)
 
-	eventService := service.NewEventService(eventRepo, userRepo, photoService, qrService)
+	eventService := service.NewEventService(
+		eventRepo,
+		userRepo,
+		purgeLogRepo,
+		templateRepo,
+		attemptRepo,
+		accessCodeRepo,
+		photoService,
+		qrService,
+		emailService,
+		cfg.Expiry.WarningDays,
+		cfg.Expiry.GraceDays,
+	)
+	trashService := service.NewTrashService(eventRepo, photoRepo, userRepo, purgeLogRepo, photoService, cfg.Trash.RetentionDays)
 
 	// Stripe service
 	stripeService := payment.NewStripeService(os.Getenv("STRIPE_SECRET_KEY"))
@@ -90,6 +170,8 @@ func main() {
 		userRepo,
 		packageRepo,
 		purchaseRepo,
+		eventRepo,
+		extensionRepo,
 	)
 
 	// Validator'ı önce tanımla
@@ -97,12 +179,22 @@ func main() {
 
 	// Handlers
 	authHandler := handler.NewAuthHandler(authService)
-	eventHandler := handler.NewEventHandler(eventService, userService, validator)
+	eventHandler := handler.NewEventHandler(eventService, userService, guestbookService, analyticsService, validator)
 	userHandler := handler.NewUserHandler(userService)
-	photoHandler := handler.NewPhotoHandler(photoService, eventService)
+	photoHandler := handler.NewPhotoHandler(photoService, eventService, validator)
 	paymentHandler := handler.NewPaymentHandler(paymentService)
 	packageService := service.NewPackageService(packageRepo)
 	creditPackageHandler := handler.NewCreditPackageHandler(packageService)
+	reactionHandler := handler.NewReactionHandler(reactionService, eventService)
+	commentHandler := handler.NewCommentHandler(commentService, eventService, validator)
+	guestbookHandler := handler.NewGuestbookHandler(guestbookService, eventService, validator)
+	guestListHandler := handler.NewGuestListHandler(guestListService, eventService, validator)
+	liveHandler := handler.NewLiveHandler(eventService, photoFeed)
+	reportHandler := handler.NewReportHandler(reportService, eventService, validator)
+	albumHandler := handler.NewAlbumHandler(albumService, eventService, validator)
+	trashHandler := handler.NewTrashHandler(trashService)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsService, eventService)
+	transferHandler := handler.NewEventTransferHandler(transferService, eventService, validator)
 
 	// Router
 	app := fiber.New(fiber.Config{
@@ -114,7 +206,8 @@ func main() {
 	// Global Middleware'ler önce tanımlanmalı
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "https://ourphotos.co, https://www.ourphotos.co, http://localhost:5173",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Device-Token, X-Guest-Token, X-Event-Access-Token",
+		ExposeHeaders:    "X-Guest-Token",
 		AllowMethods:     "GET, POST, PUT, DELETE",
 		AllowCredentials: true,
 	}))
@@ -195,6 +288,23 @@ func main() {
 		},
 	})
 
+	// 7. Yorum gönderme için özel limit
+	commentLimiter := limiter.New(limiter.Config{
+		Max:        10,
+		Expiration: 1 * time.Minute,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			if userID := c.Locals("userID"); userID != nil {
+				return fmt.Sprintf("comment_user_%v", userID)
+			}
+			return c.IP() + "_comment"
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(models.ErrorResponse(
+				"Too many comments. Please slow down.",
+			))
+		},
+	})
+
 	// Global rate limiter (varsayılan olarak tüm endpoint'ler için)
 	globalLimiter := limiter.New(limiter.Config{
 		Max:        100,
@@ -230,9 +340,27 @@ func main() {
 	auth.Post("/complete-email-change", authLimiter, userHandler.CompleteEmailChange)
 
 	// Public event routes
-	api.Get("/events/:url", publicLimiter, eventHandler.GetEventByURL)
+	api.Get("/events/:url", middleware.OptionalAuthMiddleware(), publicLimiter, eventHandler.GetEventByURL)
 	api.Post("/events/url/:url/check-password", authLimiter, eventHandler.CheckEventPassword)
+	api.Post("/events/url/:url/guest-token", publicLimiter, eventHandler.IssueGuestToken)
+	api.Get("/events/url/:url/my-uploads", publicLimiter, photoHandler.GetMyUploads)
+	api.Put("/events/url/:url/my-uploads/:id/metadata", writeLimiter, photoHandler.UpdateMyUploadMetadata)
+	api.Delete("/events/url/:url/my-uploads/:id", writeLimiter, photoHandler.DeleteMyUpload)
 	api.Get("/gallery/:url", publicLimiter, photoHandler.GetPublicEventPhotos)
+	api.Get("/gallery/:url/albums", publicLimiter, albumHandler.GetPublicAlbums)
+	api.Get("/gallery/:url/live", publicLimiter, liveHandler.StreamEventPhotos)
+	api.Post("/gallery/:url/photos/:id/reactions", publicLimiter, reactionHandler.AddReaction)
+	api.Delete("/gallery/:url/photos/:id/reactions", publicLimiter, reactionHandler.RemoveReaction)
+	api.Get("/gallery/:url/photos/:id/comments", publicLimiter, commentHandler.GetComments)
+	api.Post("/gallery/:url/photos/:id/comments", middleware.OptionalAuthMiddleware(), commentLimiter, commentHandler.CreateComment)
+	api.Get("/gallery/:url/guestbook", publicLimiter, guestbookHandler.GetEntries)
+	api.Post("/gallery/:url/guestbook", middleware.OptionalAuthMiddleware(), commentLimiter, guestbookHandler.CreateEntry)
+
+	// Davet takip route'ları
+	api.Get("/invitations/:token", publicLimiter, guestListHandler.TrackInvitationVisit)
+	api.Get("/invitations/:token/open", publicLimiter, guestListHandler.TrackInvitationOpen)
+	api.Post("/gallery/:url/photos/:id/report", writeLimiter, reportHandler.ReportPhoto)
+	api.Post("/gallery/:url/photos/:id/download", publicLimiter, analyticsHandler.RecordDownload)
 
 	// Public photo routes (authentication middleware'den ÖNCE olmalı)
 	api.Post("/events/guest-upload/:url", uploadLimiter, photoHandler.UploadPhoto)
@@ -242,6 +370,7 @@ func main() {
 
 	// Public routes (auth middleware'den ÖNCE olmalı)
 	api.Get("/payments/packages", publicLimiter, paymentHandler.GetCreditPackages)
+	api.Get("/payments/extensions", publicLimiter, paymentHandler.GetEventExtensionPackages)
 
 	// Protected routes
 	api.Use(middleware.AuthMiddleware())
@@ -260,21 +389,79 @@ func main() {
 		events.Delete("/:url", writeLimiter, eventHandler.DeleteEvent)
 		events.Post("/:url/photos", uploadLimiter, eventHandler.UploadEventPhotos)
 		events.Get("/:url/qrcode", readLimiter, eventHandler.GetEventQRCode)
+		events.Get("/:url/print", readLimiter, eventHandler.GetEventPrintPDF)
+		events.Post("/:url/cover", uploadLimiter, eventHandler.UploadCoverImage)
+		events.Delete("/:url/cover", writeLimiter, eventHandler.RemoveCoverImage)
+		events.Get("/:url/albums", readLimiter, albumHandler.GetAlbums)
+		events.Post("/:url/albums", writeLimiter, albumHandler.CreateAlbum)
+		events.Delete("/:url/albums/:id", writeLimiter, albumHandler.DeleteAlbum)
+		events.Post("/:url/duplicate", writeLimiter, eventHandler.DuplicateEvent)
+		events.Post("/:url/template", writeLimiter, eventHandler.SaveEventAsTemplate)
+		events.Get("/:url/access-codes", readLimiter, eventHandler.GetAccessCodes)
+		events.Post("/:url/access-codes", writeLimiter, eventHandler.CreateAccessCode)
+		events.Put("/:url/access-codes/:id", writeLimiter, eventHandler.UpdateAccessCode)
+		events.Delete("/:url/access-codes/:id", writeLimiter, eventHandler.DeleteAccessCode)
+		events.Get("/:url/guestbook", readLimiter, guestbookHandler.GetEventEntries)
+		events.Get("/:url/guestbook/export", readLimiter, guestbookHandler.ExportEntries)
+		events.Put("/:url/guestbook/:id", writeLimiter, guestbookHandler.ModerateEntry)
+		events.Delete("/:url/guestbook/:id", writeLimiter, guestbookHandler.DeleteEntry)
+		events.Get("/:url/guests", readLimiter, guestListHandler.GetGuests)
+		events.Post("/:url/guests", writeLimiter, guestListHandler.ImportGuests)
+		events.Delete("/:url/guests/:id", writeLimiter, guestListHandler.DeleteGuest)
+		events.Post("/:url/guests/invite", writeLimiter, guestListHandler.SendInvitations)
+		events.Post("/:url/guests/gallery-ready", writeLimiter, guestListHandler.SendGalleryReady)
+		events.Get("/:url/analytics", readLimiter, analyticsHandler.GetEventAnalytics)
+		events.Get("/:url/analytics/export", readLimiter, analyticsHandler.ExportEventAnalytics)
+		events.Get("/:url/transfer", readLimiter, transferHandler.GetTransfer)
+		events.Post("/:url/transfer", writeLimiter, transferHandler.CreateTransfer)
+		events.Delete("/:url/transfer", writeLimiter, transferHandler.CancelTransfer)
+
+		// Etkinlik devri alıcı route'ları
+		transfers := api.Group("/transfers")
+		transfers.Get("/:token", readLimiter, transferHandler.GetTransferPreview)
+		transfers.Post("/:token/accept", writeLimiter, transferHandler.AcceptTransfer)
+		transfers.Post("/:token/decline", writeLimiter, transferHandler.DeclineTransfer)
+
+		// Etkinlik şablonu route'ları
+		templates := api.Group("/event-templates")
+		templates.Get("/", readLimiter, eventHandler.GetTemplates)
+		templates.Post("/", writeLimiter, eventHandler.CreateTemplate)
+		templates.Delete("/:id", writeLimiter, eventHandler.DeleteTemplate)
 
 		// Photo routes
 		photos := api.Group("/photos")
 		photos.Get("/event/:url", readLimiter, photoHandler.GetEventPhotos)
+		photos.Get("/event/:url/guests", readLimiter, photoHandler.GetUploadsByGuest)
+		photos.Get("/search", readLimiter, photoHandler.SearchPhotos)
+		photos.Post("/bulk", writeLimiter, photoHandler.BulkPhotoOperation)
+		photos.Put("/:id/metadata", writeLimiter, photoHandler.UpdatePhotoMetadata)
 		photos.Delete("/:id", writeLimiter, photoHandler.DeletePhoto)
+		photos.Delete("/comments/:id", writeLimiter, commentHandler.DeleteComment)
+
+		// Trash routes
+		trash := api.Group("/trash")
+		trash.Get("/events", readLimiter, trashHandler.GetTrashedEvents)
+		trash.Get("/photos", readLimiter, trashHandler.GetTrashedPhotos)
+		trash.Post("/events/:id/restore", writeLimiter, trashHandler.RestoreEvent)
+		trash.Post("/photos/:id/restore", writeLimiter, trashHandler.RestorePhoto)
 
 		// Payment routes (protected)
 		payments := api.Group("/payments")
 		payments.Get("/history", readLimiter, paymentHandler.GetPurchaseHistory)
 		payments.Post("/checkout/:packageId", paymentLimiter, paymentHandler.CreateCheckoutSession)
+		payments.Get("/extensions/history", readLimiter, paymentHandler.GetEventExtensionHistory)
+		payments.Post("/extensions/:url/checkout/:packageId", paymentLimiter, paymentHandler.CreateEventExtensionCheckout)
 
 		// Credit package routes
 		packages := api.Group("/packages")
 		packages.Get("/", readLimiter, creditPackageHandler.GetAllPackages)
 		packages.Get("/:id", readLimiter, creditPackageHandler.GetPackageByID)
+
+		// Admin routes
+		admin := api.Group("/admin", middleware.AdminMiddleware(userRepo))
+		admin.Get("/reports", readLimiter, reportHandler.ListReports)
+		admin.Post("/reports/:id/resolve", writeLimiter, reportHandler.ResolveReport)
+		admin.Post("/reports/:id/dismiss", writeLimiter, reportHandler.DismissReport)
 	}
 
 	// Start server
@@ -286,16 +473,39 @@ func main() {
 	// Süresi dolmuş etkinlikleri temizleme zamanlayıcısı
 	go func() {
 		// İlk temizleme işlemi
+		if err := eventService.SendExpiryWarnings(); err != nil {
+			log.Printf("Error sending expiry warnings: %v\n", err)
+		}
 		if err := eventService.CleanupExpiredEvents(); err != nil {
 			log.Printf("Error cleaning up expired events: %v\n", err)
 		}
+		if err := trashService.PurgeExpiredTrash(); err != nil {
+			log.Printf("Error purging trash: %v\n", err)
+		}
 
 		// Her gün aynı saatte çalışacak zamanlayıcı
 		ticker := time.NewTicker(24 * time.Hour)
 		for range ticker.C {
+			if err := eventService.SendExpiryWarnings(); err != nil {
+				log.Printf("Error sending expiry warnings: %v\n", err)
+			}
 			if err := eventService.CleanupExpiredEvents(); err != nil {
 				log.Printf("Error cleaning up expired events: %v\n", err)
 			}
+			if err := trashService.PurgeExpiredTrash(); err != nil {
+				log.Printf("Error purging trash: %v\n", err)
+			}
+		}
+	}()
+
+	// Ham ziyaret kayıtlarını periyodik olarak istatistiklere topla
+	go func() {
+		ticker := time.NewTicker(service.AnalyticsAggregationInterval)
+		for {
+			if err := analyticsService.AggregateHits(); err != nil {
+				log.Printf("Error aggregating analytics: %v\n", err)
+			}
+			<-ticker.C
 		}
 	}()
 
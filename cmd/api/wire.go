@@ -11,6 +11,7 @@ import (
 	"github.com/sefazor/ourphotos-backend/pkg/email"
 	"github.com/sefazor/ourphotos-backend/pkg/payment"
 	"github.com/sefazor/ourphotos-backend/pkg/qrcode"
+	"github.com/sefazor/ourphotos-backend/pkg/realtime"
 	"github.com/sefazor/ourphotos-backend/pkg/storage"
 	"github.com/sefazor/ourphotos-backend/pkg/utils"
 )
@@ -24,6 +25,19 @@ func InitializeAPI(cfg *config.Config) (*fiber.App, error) {
 		repository.NewPhotoRepository,
 		repository.NewCreditPackageRepository,
 		repository.NewUserCreditPurchaseRepository,
+		repository.NewReactionRepository,
+		repository.NewCommentRepository,
+		repository.NewGuestbookRepository,
+		repository.NewEventGuestRepository,
+		repository.NewReportRepository,
+		repository.NewAlbumRepository,
+		repository.NewPurgeLogRepository,
+		repository.NewEventExtensionRepository,
+		repository.NewEventTemplateRepository,
+		repository.NewPasswordAttemptRepository,
+		repository.NewAccessCodeRepository,
+		repository.NewAnalyticsRepository,
+		repository.NewEventTransferRepository,
 
 		// Storage & External Services
 		storage.NewCloudflareStorage,
@@ -31,6 +45,7 @@ func InitializeAPI(cfg *config.Config) (*fiber.App, error) {
 		email.NewEmailService,
 		payment.NewStripeService,
 		qrcode.NewQRService,
+		realtime.NewBroker,
 
 		// Services
 		service.NewAuthService,
@@ -39,6 +54,15 @@ func InitializeAPI(cfg *config.Config) (*fiber.App, error) {
 		service.NewPhotoService,
 		service.NewPackageService,
 		service.NewPaymentService,
+		service.NewReactionService,
+		service.NewCommentService,
+		service.NewGuestbookService,
+		service.NewGuestListService,
+		service.NewReportService,
+		service.NewAlbumService,
+		service.NewTrashService,
+		service.NewAnalyticsService,
+		service.NewEventTransferService,
 
 		// Validator
 		utils.NewValidator,
@@ -50,6 +74,16 @@ func InitializeAPI(cfg *config.Config) (*fiber.App, error) {
 		handler.NewPhotoHandler,
 		handler.NewPaymentHandler,
 		handler.NewCreditPackageHandler,
+		handler.NewReactionHandler,
+		handler.NewCommentHandler,
+		handler.NewGuestbookHandler,
+		handler.NewGuestListHandler,
+		handler.NewLiveHandler,
+		handler.NewReportHandler,
+		handler.NewAlbumHandler,
+		handler.NewTrashHandler,
+		handler.NewAnalyticsHandler,
+		handler.NewEventTransferHandler,
 
 		// Middleware
 		middleware.AuthMiddleware,
@@ -68,6 +102,14 @@ func initializeFiberApp(
 	photoHandler *handler.PhotoHandler,
 	paymentHandler *handler.PaymentHandler,
 	packageHandler *handler.CreditPackageHandler,
+	reactionHandler *handler.ReactionHandler,
+	commentHandler *handler.CommentHandler,
+	liveHandler *handler.LiveHandler,
+	reportHandler *handler.ReportHandler,
+	albumHandler *handler.AlbumHandler,
+	trashHandler *handler.TrashHandler,
+	analyticsHandler *handler.AnalyticsHandler,
+	transferHandler *handler.EventTransferHandler,
 	authMiddleware func() fiber.Handler,
 ) *fiber.App {
 	app := fiber.New()
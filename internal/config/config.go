@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 )
 
 type R2Config struct {
@@ -20,6 +22,46 @@ type Config struct {
 		Token     string
 		Hash      string // Images CDN URL'leri için hash değeri
 	}
+	Moderation struct {
+		ReportHideThreshold int // Bu kadar açık şikayet alan fotoğraf otomatik gizlenir
+	}
+	Trash struct {
+		RetentionDays int // Çöp kutusundaki kayıtlar bu kadar gün sonra kalıcı olarak silinir
+	}
+	Expiry struct {
+		WarningDays []int // Etkinlik sahibine süre dolmadan bu kadar gün önce uyarı gönderilir
+		GraceDays   int   // Süresi dolan etkinlik bu kadar gün salt okunur kalır, sonra silinir
+	}
+	QR struct {
+		BaseURL string // QR kodlara yazılan etkinlik adresinin öneki
+	}
+}
+
+// getEnvInt ortam değişkenini tamsayı olarak okur, tanımlı veya geçerli değilse varsayılanı döner
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvIntList virgülle ayrılmış tamsayı listesini okur, geçersiz bir değer varsa varsayılanı döner
+func getEnvIntList(key string, defaultValue []int) []int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return defaultValue
+	}
+
+	var values []int
+	for _, part := range strings.Split(raw, ",") {
+		value, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || value <= 0 {
+			return defaultValue
+		}
+		values = append(values, value)
+	}
+	return values
 }
 
 func LoadConfig() *Config {
@@ -37,6 +79,22 @@ func LoadConfig() *Config {
 	cfg.CloudflareImages.Token = os.Getenv("CLOUDFLARE_IMAGES_TOKEN")
 	cfg.CloudflareImages.Hash = os.Getenv("CLOUDFLARE_IMAGES_HASH")
 
+	// Moderasyon config
+	cfg.Moderation.ReportHideThreshold = getEnvInt("REPORT_HIDE_THRESHOLD", 3)
+
+	// Çöp kutusu config
+	cfg.Trash.RetentionDays = getEnvInt("TRASH_RETENTION_DAYS", 30)
+
+	// Etkinlik süresi config
+	cfg.Expiry.WarningDays = getEnvIntList("EXPIRY_WARNING_DAYS", []int{7, 1})
+	cfg.Expiry.GraceDays = getEnvInt("EXPIRY_GRACE_DAYS", 14)
+
+	// QR kod config
+	cfg.QR.BaseURL = os.Getenv("QR_BASE_URL")
+	if cfg.QR.BaseURL == "" {
+		cfg.QR.BaseURL = "https://ourphotos.co/e/"
+	}
+
 	// Debug için
 	fmt.Printf("Debug - Loading Cloudflare config: AccountID=%s, TokenLength=%d, Hash=%s\n",
 		cfg.CloudflareImages.AccountID, len(cfg.CloudflareImages.Token), cfg.CloudflareImages.Hash)
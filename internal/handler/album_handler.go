@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/service"
+	"github.com/sefazor/ourphotos-backend/pkg/utils"
+)
+
+type AlbumHandler struct {
+	albumService *service.AlbumService
+	eventService *service.EventService
+	validator    *utils.Validator
+}
+
+func NewAlbumHandler(albumService *service.AlbumService, eventService *service.EventService, validator *utils.Validator) *AlbumHandler {
+	return &AlbumHandler{
+		albumService: albumService,
+		eventService: eventService,
+		validator:    validator,
+	}
+}
+
+// GetPublicAlbums public galeride albüm listesini döndürür
+func (h *AlbumHandler) GetPublicAlbums(c *fiber.Ctx) error {
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return err
+	}
+
+	if denied, err := denyPublicEventAccess(c, h.eventService, event); denied {
+		return err
+	}
+
+	albums, err := h.albumService.ListAlbums(event)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(albums, "Albums retrieved successfully"))
+}
+
+func (h *AlbumHandler) GetAlbums(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	if event.UserID != userID {
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to access this event"))
+	}
+
+	albums, err := h.albumService.ListAlbums(event)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(albums, "Albums retrieved successfully"))
+}
+
+func (h *AlbumHandler) CreateAlbum(c *fiber.Ctx) error {
+	var req models.CreateAlbumRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	album, err := h.albumService.CreateAlbum(event.ID, userID, req)
+	if err != nil {
+		switch err.Error() {
+		case "unauthorized":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to edit this event"))
+		case "album name is required":
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse(album, "Album created successfully"))
+}
+
+func (h *AlbumHandler) DeleteAlbum(c *fiber.Ctx) error {
+	albumID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid album ID"))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	if err := h.albumService.DeleteAlbum(event.ID, uint(albumID), userID); err != nil {
+		switch err.Error() {
+		case "unauthorized":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to edit this event"))
+		case "album not found", "event not found":
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(nil, "Album deleted successfully"))
+}
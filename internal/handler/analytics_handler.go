@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/service"
+)
+
+type AnalyticsHandler struct {
+	analyticsService *service.AnalyticsService
+	eventService     *service.EventService
+}
+
+func NewAnalyticsHandler(analyticsService *service.AnalyticsService, eventService *service.EventService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+		eventService:     eventService,
+	}
+}
+
+// analyticsErrorResponse analitik servis hatalarını HTTP durum kodlarına eşler
+func analyticsErrorResponse(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "event not found", "photo not found":
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+	case "unauthorized":
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to view this event's analytics"))
+	case "invalid date range", "date range is too large for the selected interval":
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	case "invalid interval":
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid interval, must be hour or day"))
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+}
+
+// parseAnalyticsTime RFC3339 veya YYYY-MM-DD biçimindeki tarih query parametresini okur
+func parseAnalyticsTime(c *fiber.Ctx, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("Invalid %s date, must be RFC3339 or YYYY-MM-DD", key)
+}
+
+// analyticsQuery from, to ve interval query parametrelerini okur
+func analyticsQuery(c *fiber.Ctx) (*time.Time, *time.Time, string, error) {
+	from, err := parseAnalyticsTime(c, "from")
+	if err != nil {
+		return nil, nil, "", err
+	}
+	to, err := parseAnalyticsTime(c, "to")
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return from, to, c.Query("interval"), nil
+}
+
+// GetEventAnalytics etkinlik sahibine ziyaret, tarama, indirme ve yükleme istatistiklerini döndürür
+func (h *AnalyticsHandler) GetEventAnalytics(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	from, to, interval, err := analyticsQuery(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	analytics, err := h.analyticsService.GetEventAnalytics(event.ID, userID, from, to, interval)
+	if err != nil {
+		return analyticsErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(analytics, "Event analytics retrieved successfully"))
+}
+
+// ExportEventAnalytics etkinlik istatistiklerini CSV dosyası olarak indirir
+func (h *AnalyticsHandler) ExportEventAnalytics(c *fiber.Ctx) error {
+	url := c.Params("url")
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(url)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	from, to, interval, err := analyticsQuery(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	data, err := h.analyticsService.ExportEventAnalytics(event.ID, userID, from, to, interval)
+	if err != nil {
+		return analyticsErrorResponse(c, err)
+	}
+
+	c.Set("Content-Type", "text/csv; charset=utf-8")
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=event-%s-analytics.csv", url))
+	return c.Send(data)
+}
+
+// RecordDownload galeriden bir fotoğrafın indirildiğini kaydeder
+func (h *AnalyticsHandler) RecordDownload(c *fiber.Ctx) error {
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return err
+	}
+
+	if denied, err := denyPublicEventAccess(c, h.eventService, event); denied {
+		return err
+	}
+
+	photoID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid photo ID"))
+	}
+
+	guestID, _ := h.eventService.ResolveGuestID(event.ID, guestTokenFromRequest(c, event))
+	if err := h.analyticsService.RecordDownload(event.ID, uint(photoID), guestID, c.IP()); err != nil {
+		return analyticsErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(nil, "Download recorded"))
+}
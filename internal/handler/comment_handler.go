@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/service"
+	"github.com/sefazor/ourphotos-backend/pkg/utils"
+)
+
+type CommentHandler struct {
+	commentService *service.CommentService
+	eventService   *service.EventService
+	validator      *utils.Validator
+}
+
+func NewCommentHandler(commentService *service.CommentService, eventService *service.EventService, validator *utils.Validator) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+		eventService:   eventService,
+		validator:      validator,
+	}
+}
+
+func (h *CommentHandler) GetComments(c *fiber.Ctx) error {
+	photoID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid photo ID"))
+	}
+
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return err
+	}
+
+	if denied, err := denyPublicEventAccess(c, h.eventService, event); denied {
+		return err
+	}
+
+	comments, err := h.commentService.ListComments(event.ID, uint(photoID))
+	if err != nil {
+		if err.Error() == "photo not found" {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(comments, "Comments retrieved successfully"))
+}
+
+func (h *CommentHandler) CreateComment(c *fiber.Ctx) error {
+	photoID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid photo ID"))
+	}
+
+	var req models.CreateCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return err
+	}
+
+	if denied, err := denyPublicEventAccess(c, h.eventService, event); denied {
+		return err
+	}
+
+	if denied, err := denyExpiredEvent(c, event); denied {
+		return err
+	}
+
+	// Giriş yapmış kullanıcı varsa al, yoksa 0 (misafir)
+	var userID uint = 0
+	if id, ok := c.Locals("userID").(uint); ok {
+		userID = id
+	}
+
+	comment, err := h.commentService.CreateComment(event, uint(photoID), userID, req)
+	if err != nil {
+		switch err.Error() {
+		case "comments are disabled for this event":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse(err.Error()))
+		case "photo not found", "parent comment not found":
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		case "guest name is required", "comment body is required":
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse(comment, "Comment posted successfully"))
+}
+
+func (h *CommentHandler) DeleteComment(c *fiber.Ctx) error {
+	commentID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid comment ID"))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.commentService.DeleteComment(uint(commentID), userID); err != nil {
+		switch err.Error() {
+		case "unauthorized":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to delete this comment"))
+		case "comment not found", "event not found":
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(nil, "Comment deleted successfully"))
+}
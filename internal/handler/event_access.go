@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/service"
+	jwtPkg "github.com/sefazor/ourphotos-backend/pkg/jwt"
+)
+
+// DeviceTokenHeader misafirin cihazını tanımlayan, istemci tarafından üretilen anonim token header'ı
+const DeviceTokenHeader = "X-Device-Token"
+
+// GuestTokenHeader sunucunun imzaladığı, etkinliğe özel misafir kimliği token header'ı
+const GuestTokenHeader = "X-Guest-Token"
+
+// EventAccessHeader parola korumalı etkinlik için imzalı erişim tokeni header'ı
+const EventAccessHeader = "X-Event-Access-Token"
+
+func accessCookieName(eventURL string) string {
+	return fmt.Sprintf("event_%s_access", eventURL)
+}
+
+func guestCookieName(eventURL string) string {
+	return fmt.Sprintf("event_%s_guest", eventURL)
+}
+
+// guestTokenFromRequest misafir tokenini önce header'dan, yoksa cookie'den okur
+func guestTokenFromRequest(c *fiber.Ctx, event *models.Event) string {
+	if token := c.Get(GuestTokenHeader); token != "" {
+		return token
+	}
+	return c.Cookies(guestCookieName(event.URL))
+}
+
+// ensureGuestIdentity istekteki misafir kimliğini çözer, geçerli bir token yoksa yenisini
+// üretip cookie ve response header'ı olarak döner
+func ensureGuestIdentity(c *fiber.Ctx, eventService *service.EventService, event *models.Event) (string, error) {
+	if guestID, err := eventService.ResolveGuestID(event.ID, guestTokenFromRequest(c, event)); err == nil {
+		return guestID, nil
+	}
+
+	guest, err := eventService.IssueGuestToken(event.ID)
+	if err != nil {
+		return "", err
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     guestCookieName(event.URL),
+		Value:    guest.GuestToken,
+		Expires:  time.Now().Add(jwtPkg.TokenExpiryGuest),
+		HTTPOnly: true,
+	})
+	c.Set(GuestTokenHeader, guest.GuestToken)
+
+	return guest.GuestID, nil
+}
+
+// redirectMovedEvent URL'si değiştirilmiş bir etkinliğe eski URL ile gelen isteği güncel URL'ye yönlendirir.
+// Etkinlik eski URL'sine geri dönebileceğinden yönlendirme tarayıcıda önbelleğe alınmaması için
+// geçicidir ve istek metodu korunur. Yönlendirme yapıldıysa true döner.
+func redirectMovedEvent(c *fiber.Ctx, eventService *service.EventService) (bool, error) {
+	oldURL := c.Params("url")
+	newURL, err := eventService.ResolveMovedURL(oldURL)
+	if err != nil {
+		return false, nil
+	}
+
+	segments := strings.Split(c.Path(), "/")
+	for i, segment := range segments {
+		if segment == oldURL {
+			segments[i] = newURL
+			break
+		}
+	}
+
+	target := strings.Join(segments, "/")
+	if query := string(c.Request().URI().QueryString()); query != "" {
+		target += "?" + query
+	}
+
+	return true, c.Redirect(target, fiber.StatusTemporaryRedirect)
+}
+
+// findPublicEvent public endpoint'lerde istekteki URL'ye ait etkinliği bulur. Etkinlik eski bir
+// URL ile istendiyse güncel URL'ye yönlendirir, hiç bulunamazsa 404 döner. Yanıt yazıldıysa
+// etkinlik boş döner.
+func findPublicEvent(c *fiber.Ctx, eventService *service.EventService) (*models.Event, error) {
+	event, err := eventService.GetEventByURL(c.Params("url"))
+	if err == nil {
+		return event, nil
+	}
+
+	if moved, err := redirectMovedEvent(c, eventService); moved {
+		return nil, err
+	}
+	return nil, c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+}
+
+// denyPublicEventAccess public endpoint'ler için etkinliğe erişimi kontrol eder.
+// Erişim yoksa hata yanıtını yazar ve true döner.
+func denyPublicEventAccess(c *fiber.Ctx, eventService *service.EventService, event *models.Event) (bool, error) {
+	// Etkinlik public değilse erişimi engelle
+	if !event.IsPublic {
+		return true, c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("This event is private"))
+	}
+
+	return denyLockedEvent(c, eventService, event)
+}
+
+// eventAccessRole parola korumalı etkinlikte istekteki imzalı erişim tokenini doğrular ve verdiği rolü döndürür
+func eventAccessRole(c *fiber.Ctx, eventService *service.EventService, event *models.Event) (string, error) {
+	token := c.Get(EventAccessHeader)
+	if token == "" {
+		token = c.Cookies(accessCookieName(event.URL))
+	}
+
+	return eventService.VerifyEventAccess(event, token)
+}
+
+// denyLockedEvent parola korumalı etkinlikte imzalı erişim tokenini doğrular.
+// Token yoksa, süresi dolduysa, parola değiştiyse veya erişim kodu iptal edildiyse hata yanıtını yazar ve true döner.
+func denyLockedEvent(c *fiber.Ctx, eventService *service.EventService, event *models.Event) (bool, error) {
+	if _, err := eventAccessRole(c, eventService, event); err != nil {
+		return true, c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse("This event requires a password"))
+	}
+
+	return false, nil
+}
+
+// denyUploadAccess parola korumalı etkinlikte erişim tokeninin yükleme yetkisi verdiğini doğrular.
+// Sadece görüntüleme yetkili erişim kodlarıyla yapılan yüklemeleri engeller.
+func denyUploadAccess(c *fiber.Ctx, eventService *service.EventService, event *models.Event) (bool, error) {
+	role, err := eventAccessRole(c, eventService, event)
+	if err != nil {
+		return true, c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse("This event requires a password"))
+	}
+
+	if role != models.AccessRoleUpload {
+		return true, c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("Your access code does not allow uploads"))
+	}
+
+	return false, nil
+}
+
+// denyClosedUploads etkinliğin yükleme durumunu kontrol eder.
+// Yükleme kabul edilmiyorsa hata yanıtını yazar ve true döner.
+func denyClosedUploads(c *fiber.Ctx, event *models.Event) (bool, error) {
+	switch service.UploadStatus(event, time.Now()) {
+	case models.UploadStatusExpired:
+		return true, c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("This event has expired"))
+	case models.UploadStatusDisabled:
+		return true, c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("Guest uploads are not allowed for this event"))
+	case models.UploadStatusScheduled:
+		return true, c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("Uploads for this event have not opened yet"))
+	case models.UploadStatusClosed:
+		return true, c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("Uploads for this event are closed"))
+	}
+	return false, nil
+}
+
+// denyExpiredEvent süresi dolan ve salt okunur olan etkinliklerde yazma isteklerini engeller.
+// Engellendiyse hata yanıtını yazar ve true döner.
+func denyExpiredEvent(c *fiber.Ctx, event *models.Event) (bool, error) {
+	if service.IsEventExpired(event, time.Now()) {
+		return true, c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("This event has expired and is read-only"))
+	}
+	return false, nil
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+)
+
+// accessCodeErrorResponse erişim kodu servis hatalarını HTTP durum kodlarına eşler
+func accessCodeErrorResponse(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "event not found", "access code not found":
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+	case "unauthorized":
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to manage this event"))
+	case "event is not password protected", "label is required", "expiry must be in the future":
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	case "access code is already in use":
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse(err.Error()))
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+}
+
+func (h *EventHandler) GetAccessCodes(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	codes, err := h.eventService.GetAccessCodes(event.ID, userID)
+	if err != nil {
+		return accessCodeErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(codes, "Access codes retrieved successfully"))
+}
+
+// CreateAccessCode etkinlik için yeni bir erişim kodu oluşturur. Kodun açık hali sadece bu yanıtta döner.
+func (h *EventHandler) CreateAccessCode(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	var req models.CreateAccessCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	code, err := h.eventService.CreateAccessCode(event.ID, userID, req)
+	if err != nil {
+		return accessCodeErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse(code, "Access code created successfully"))
+}
+
+func (h *EventHandler) UpdateAccessCode(c *fiber.Ctx) error {
+	codeID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid access code ID"))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	var req models.UpdateAccessCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	code, err := h.eventService.UpdateAccessCode(event.ID, userID, uint(codeID), req)
+	if err != nil {
+		return accessCodeErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(code, "Access code updated successfully"))
+}
+
+// DeleteAccessCode kodu iptal eder, bu kodla giriş yapmış misafirlerin erişimi de sona erer
+func (h *EventHandler) DeleteAccessCode(c *fiber.Ctx) error {
+	codeID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid access code ID"))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	if err := h.eventService.DeleteAccessCode(event.ID, userID, uint(codeID)); err != nil {
+		return accessCodeErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(nil, "Access code deleted successfully"))
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -9,20 +11,26 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/sefazor/ourphotos-backend/internal/models"
 	"github.com/sefazor/ourphotos-backend/internal/service"
+	jwtPkg "github.com/sefazor/ourphotos-backend/pkg/jwt"
+	"github.com/sefazor/ourphotos-backend/pkg/qrcode"
 	"github.com/sefazor/ourphotos-backend/pkg/utils"
 )
 
 type EventHandler struct {
-	eventService *service.EventService
-	userService  *service.UserService
-	validator    *utils.Validator
+	eventService     *service.EventService
+	userService      *service.UserService
+	guestbookService *service.GuestbookService
+	analyticsService *service.AnalyticsService
+	validator        *utils.Validator
 }
 
-func NewEventHandler(eventService *service.EventService, userService *service.UserService, validator *utils.Validator) *EventHandler {
+func NewEventHandler(eventService *service.EventService, userService *service.UserService, guestbookService *service.GuestbookService, analyticsService *service.AnalyticsService, validator *utils.Validator) *EventHandler {
 	return &EventHandler{
-		eventService: eventService,
-		userService:  userService,
-		validator:    validator,
+		eventService:     eventService,
+		userService:      userService,
+		guestbookService: guestbookService,
+		analyticsService: analyticsService,
+		validator:        validator,
 	}
 }
 
@@ -46,6 +54,12 @@ func (h *EventHandler) CreateEvent(c *fiber.Ctx) error {
 		if strings.Contains(err.Error(), "not allowed") {
 			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse(err.Error()))
 		}
+		if err.Error() == "template not found" {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		}
+		if strings.HasPrefix(err.Error(), "upload window") {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
 	}
 
@@ -105,19 +119,39 @@ func (h *EventHandler) GetUserEvents(c *fiber.Ctx) error {
 
 	fmt.Printf("Getting events for userID: %d\n", userID)
 
+	page, limit := parsePagination(c)
+	query := models.EventListQuery{
+		Page:              page,
+		Limit:             limit,
+		Search:            c.Query("q"),
+		Status:            c.Query("status"),
+		PasswordProtected: c.QueryBool("password_protected"),
+		PendingModeration: c.QueryBool("pending_moderation"),
+		Sort:              c.Query("sort"),
+		Order:             c.Query("order"),
+	}
+
 	// Kullanıcının eventlerini getir
-	events, err := h.eventService.GetUserEvents(userID)
+	events, total, err := h.eventService.GetUserEvents(userID, query)
 	if err != nil {
+		switch err.Error() {
+		case "invalid status filter":
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid status, must be active or expired"))
+		case "invalid sort field":
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid sort, must be created, expiry or photos"))
+		case "invalid sort order":
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid order, must be asc or desc"))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
 			"error":   err.Error(),
 		})
 	}
 
-	return c.JSON(fiber.Map{
-		"success": true,
-		"data":    events,
-	})
+	return c.JSON(models.SuccessResponse(models.PaginatedResponse{
+		Items:      events,
+		Pagination: models.NewPagination(page, limit, total),
+	}, "Events retrieved successfully"))
 }
 
 func (h *EventHandler) UpdateEvent(c *fiber.Ctx) error {
@@ -128,6 +162,10 @@ func (h *EventHandler) UpdateEvent(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
 	}
 
+	if err := h.validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
 	userIDRaw := c.Locals("userID")
 	if userIDRaw == nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse("Unauthorized"))
@@ -151,6 +189,9 @@ func (h *EventHandler) UpdateEvent(c *fiber.Ctx) error {
 
 	updatedEvent, err := h.eventService.UpdateEvent(event.ID, userID, req)
 	if err != nil {
+		if err.Error() == "url is already taken" {
+			return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse(err.Error()))
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
 	}
 
@@ -191,9 +232,9 @@ func (h *EventHandler) DeleteEvent(c *fiber.Ctx) error {
 func (h *EventHandler) GetEventByURL(c *fiber.Ctx) error {
 	url := c.Params("url")
 
-	event, err := h.eventService.GetEventByURL(url)
-	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return err
 	}
 
 	// Public event kontrolü
@@ -210,7 +251,68 @@ func (h *EventHandler) GetEventByURL(c *fiber.Ctx) error {
 		}
 	}
 
-	return c.JSON(models.SuccessResponse(event, "Event retrieved successfully"))
+	// İlk ziyarette misafire anonim kimlik tokeni ver
+	guestID, err := ensureGuestIdentity(c, h.eventService, event)
+	if err != nil {
+		fmt.Printf("Error issuing guest token for event %s: %v\n", url, err)
+	}
+
+	// Etkinlik sahibinin kendi ziyaretleri istatistiklere dahil edilmez
+	if ownerID, ok := c.Locals("userID").(uint); !ok || ownerID != event.UserID {
+		if err := h.analyticsService.RecordView(event.ID, c.Query(qrcode.SourceParam), guestID, c.IP()); err != nil {
+			fmt.Printf("Error recording view for event %s: %v\n", url, err)
+		}
+	}
+
+	response := models.PublicEventResponse{EventResponse: h.eventService.BuildEventResponse(event)}
+
+	// Anı defteri sadece etkinliğe erişimi olan ziyaretçilere gösterilir
+	if !event.GuestbookDisabled {
+		if _, err := eventAccessRole(c, h.eventService, event); err == nil {
+			page, limit := parsePagination(c)
+			entries, total, err := h.guestbookService.ListPublicEntries(event, page, limit)
+			if err != nil {
+				fmt.Printf("Error loading guestbook for event %s: %v\n", url, err)
+			} else {
+				response.Guestbook = &models.PaginatedResponse{
+					Items:      entries,
+					Pagination: models.NewPagination(page, limit, total),
+				}
+			}
+		}
+	}
+
+	return c.JSON(models.SuccessResponse(response, "Event retrieved successfully"))
+}
+
+// IssueGuestToken misafire etkinlik için anonim kimlik tokeni verir, geçerli token varsa onu korur
+func (h *EventHandler) IssueGuestToken(c *fiber.Ctx) error {
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return err
+	}
+
+	token := guestTokenFromRequest(c, event)
+	if guestID, err := h.eventService.ResolveGuestID(event.ID, token); err == nil {
+		return c.JSON(models.SuccessResponse(models.GuestTokenResponse{
+			GuestID:    guestID,
+			GuestToken: token,
+		}, "Guest token is valid"))
+	}
+
+	guest, err := h.eventService.IssueGuestToken(event.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     guestCookieName(event.URL),
+		Value:    guest.GuestToken,
+		Expires:  time.Now().Add(jwtPkg.TokenExpiryGuest),
+		HTTPOnly: true,
+	})
+
+	return c.JSON(models.SuccessResponse(guest, "Guest token issued"))
 }
 
 func (h *EventHandler) CheckEventPassword(c *fiber.Ctx) error {
@@ -224,23 +326,35 @@ func (h *EventHandler) CheckEventPassword(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.eventService.CheckEventPassword(url, req.Password); err != nil {
+	access, err := h.eventService.CheckEventPassword(url, req.Password, c.IP())
+	if err != nil {
+		if err.Error() == "event not found" {
+			// Eski URL ile gelindiyse etkinliğin yeni URL'sine yönlendir
+			if moved, err := redirectMovedEvent(c, h.eventService); moved {
+				return err
+			}
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+		}
+		var locked *service.PasswordLockedError
+		if errors.As(err, &locked) {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+			return c.Status(fiber.StatusTooManyRequests).JSON(models.ErrorResponse("Too many failed attempts, please try again later"))
+		}
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"success": false,
 			"error":   "Incorrect password",
 		})
 	}
 
-	// Başarılı giriş için cookie oluştur
-	cookie := new(fiber.Cookie)
-	cookie.Name = fmt.Sprintf("event_%s_access", url)
-	cookie.Value = "true"
-	cookie.Expires = time.Now().Add(24 * time.Hour)
-	c.Cookie(cookie)
-
-	return c.JSON(fiber.Map{
-		"success": true,
+	// Başarılı giriş için imzalı erişim tokenini cookie olarak da ver
+	c.Cookie(&fiber.Cookie{
+		Name:     accessCookieName(url),
+		Value:    access.AccessToken,
+		Expires:  access.ExpiresAt,
+		HTTPOnly: true,
 	})
+
+	return c.JSON(models.SuccessResponse(access, "Password verified"))
 }
 
 func (h *EventHandler) UploadEventPhotos(c *fiber.Ctx) error {
@@ -263,9 +377,19 @@ func (h *EventHandler) UploadEventPhotos(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
 	}
 
-	// Yetki kontrolü - sadece AllowGuestUploads false ise ve misafirse engelle
-	if userID == 0 && !event.AllowGuestUploads {
-		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("Guest uploads are not allowed for this event"))
+	// Süresi dolan etkinlik salt okunurdur, etkinlik sahibi dahil kimse yükleme yapamaz
+	if denied, err := denyExpiredEvent(c, event); denied {
+		return err
+	}
+
+	// Etkinlik sahibi parola ve yükleme penceresinden bağımsız olarak yükleyebilir
+	if userID != event.UserID {
+		if denied, err := denyUploadAccess(c, h.eventService, event); denied {
+			return err
+		}
+		if denied, err := denyClosedUploads(c, event); denied {
+			return err
+		}
 	}
 
 	// Multipart form
@@ -289,7 +413,7 @@ func (h *EventHandler) UploadEventPhotos(c *fiber.Ctx) error {
 		photo, err := h.eventService.UploadEventPhoto(event.ID, userID, file)
 		if err != nil {
 			fmt.Printf("Error uploading file %s: %v\n", file.Filename, err)
-			return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+			return uploadErrorResponse(c, err)
 		}
 		fmt.Printf("Successfully uploaded file %s\n", file.Filename)
 		uploadedPhotos = append(uploadedPhotos, *photo)
@@ -299,6 +423,54 @@ func (h *EventHandler) UploadEventPhotos(c *fiber.Ctx) error {
 	return c.JSON(models.SuccessResponse(uploadedPhotos, "Photos uploaded successfully"))
 }
 
+// UploadCoverImage etkinlik için ayrı bir kapak görseli yükler
+func (h *EventHandler) UploadCoverImage(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("No file uploaded"))
+	}
+
+	response, err := h.eventService.UploadCoverImage(event.ID, userID, file)
+	if err != nil {
+		switch err.Error() {
+		case "unauthorized":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to update this event"))
+		case "unsupported image type":
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(response, "Cover image uploaded successfully"))
+}
+
+// RemoveCoverImage etkinliğin kapak görselini kaldırır
+func (h *EventHandler) RemoveCoverImage(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	response, err := h.eventService.RemoveCoverImage(event.ID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized" {
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to update this event"))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(response, "Cover image removed successfully"))
+}
+
 // GetEventQRCode, belirtilen etkinlik için QR kodu oluşturur ve döndürür
 func (h *EventHandler) GetEventQRCode(c *fiber.Ctx) error {
 	url := c.Params("url")
@@ -332,14 +504,111 @@ func (h *EventHandler) GetEventQRCode(c *fiber.Ctx) error {
 		size = 256 // Geçersiz boyut için varsayılan değer
 	}
 
+	opts, err := qrOptionsFromQuery(c, size)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	format := c.Query("format", qrcode.FormatPNG)
+	contentType, ok := qrContentTypes[format]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid format, must be png, svg or pdf"))
+	}
+
 	// QR kodu oluştur
-	qrCode, err := h.eventService.GetEventQRCode(event.ID, size)
+	qrCode, err := h.eventService.GetEventQRCode(event.ID, format, opts, c.QueryBool("logo"))
 	if err != nil {
+		switch err.Error() {
+		case "foreground and background colors must differ", "event has no cover image to use as logo":
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
 	}
 
-	// QR kodu PNG olarak döndür
-	c.Set("Content-Type", "image/png")
-	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=event-%s-qr.png", url))
+	// QR kodu istenen formatta döndür
+	c.Set("Content-Type", contentType)
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=event-%s-qr.%s", url, format))
 	return c.Send(qrCode)
 }
+
+// GetEventPrintPDF etkinlik için baskıya hazır afiş veya masa kartı PDF'i döndürür
+func (h *EventHandler) GetEventPrintPDF(c *fiber.Ctx) error {
+	url := c.Params("url")
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(url)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	if event.UserID != userID {
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to access this event"))
+	}
+
+	// PDF'te QR kod vektörel çizildiğinden piksel boyutu kullanılmaz
+	opts, err := qrOptionsFromQuery(c, 0)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	printOpts := models.PrintOptions{
+		Layout: c.Query("layout", models.PrintLayoutA4),
+		Sheet:  c.Query("sheet"),
+		Copies: c.QueryInt("copies", 1),
+	}
+
+	data, err := h.eventService.GetEventPrintPDF(event.ID, printOpts, opts, c.QueryBool("logo"))
+	if err != nil {
+		switch {
+		case err.Error() == "invalid print layout":
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid layout, must be a4, a5, letter or card"))
+		case err.Error() == "foreground and background colors must differ",
+			err.Error() == "event has no cover image to use as logo",
+			err.Error() == "multiple copies are only supported for table cards",
+			err.Error() == "invalid sheet size, must be a4 or letter",
+			strings.HasPrefix(err.Error(), "at most"):
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	c.Set("Content-Type", "application/pdf")
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=event-%s-%s.pdf", url, printOpts.Layout))
+	return c.Send(data)
+}
+
+// qrContentTypes desteklenen QR kod formatlarının içerik türleri
+var qrContentTypes = map[string]string{
+	qrcode.FormatPNG: "image/png",
+	qrcode.FormatSVG: "image/svg+xml",
+	qrcode.FormatPDF: "application/pdf",
+}
+
+// qrOptionsFromQuery fg, bg ve margin query parametrelerinden QR kod görünüm ayarlarını okur
+func qrOptionsFromQuery(c *fiber.Ctx, size int) (qrcode.Options, error) {
+	opts := qrcode.DefaultOptions(size)
+
+	if fg := c.Query("fg"); fg != "" {
+		color, err := qrcode.ParseHexColor(fg)
+		if err != nil {
+			return opts, errors.New("Invalid foreground color")
+		}
+		opts.Foreground = color
+	}
+
+	if bg := c.Query("bg"); bg != "" {
+		color, err := qrcode.ParseHexColor(bg)
+		if err != nil {
+			return opts, errors.New("Invalid background color")
+		}
+		opts.Background = color
+	}
+
+	// Boşluk modül cinsindendir, 0 ile 16 arasında olmalı
+	opts.QuietZone = c.QueryInt("margin", qrcode.DefaultQuietZone)
+	if opts.QuietZone < 0 || opts.QuietZone > 16 {
+		return opts, errors.New("Invalid margin, must be between 0 and 16")
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+)
+
+// DuplicateEvent etkinliğin ayarlarını fotoğraflar olmadan yeni bir etkinliğe kopyalar
+func (h *EventHandler) DuplicateEvent(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	var req models.DuplicateEventRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+		}
+	}
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	duplicated, err := h.eventService.DuplicateEvent(event.ID, userID, req)
+	if err != nil {
+		switch err.Error() {
+		case "event not found":
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		case "unauthorized":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to duplicate this event"))
+		case "event limit exceeded":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse(duplicated, "Event duplicated successfully"))
+}
+
+// SaveEventAsTemplate etkinliğin ayarlarını yeni bir şablon olarak kaydeder
+func (h *EventHandler) SaveEventAsTemplate(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	var req models.SaveEventTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	template, err := h.eventService.SaveEventAsTemplate(event.ID, userID, req.Name)
+	if err != nil {
+		switch err.Error() {
+		case "event not found":
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		case "unauthorized":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to access this event"))
+		case "template name is required":
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse(template, "Template saved successfully"))
+}
+
+func (h *EventHandler) GetTemplates(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	templates, err := h.eventService.GetUserTemplates(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(templates, "Templates retrieved successfully"))
+}
+
+func (h *EventHandler) CreateTemplate(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	var req models.EventTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	template, err := h.eventService.CreateTemplate(userID, req)
+	if err != nil {
+		switch err.Error() {
+		case "template name is required", "password is required for password protected templates":
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse(template, "Template created successfully"))
+}
+
+func (h *EventHandler) DeleteTemplate(c *fiber.Ctx) error {
+	templateID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid template ID"))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.eventService.DeleteTemplate(uint(templateID), userID); err != nil {
+		if err.Error() == "template not found" {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(nil, "Template deleted successfully"))
+}
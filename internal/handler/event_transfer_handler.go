@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/service"
+	"github.com/sefazor/ourphotos-backend/pkg/utils"
+)
+
+type EventTransferHandler struct {
+	transferService *service.EventTransferService
+	eventService    *service.EventService
+	validator       *utils.Validator
+}
+
+func NewEventTransferHandler(transferService *service.EventTransferService, eventService *service.EventService, validator *utils.Validator) *EventTransferHandler {
+	return &EventTransferHandler{
+		transferService: transferService,
+		eventService:    eventService,
+		validator:       validator,
+	}
+}
+
+// transferErrorResponse sahiplik devri servis hatalarını HTTP durum kodlarına eşler
+func transferErrorResponse(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "event not found", "transfer not found":
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+	case "unauthorized":
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to transfer this event"))
+	case "transfer was sent to a different email", "email address is not verified":
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse(err.Error()))
+	case "cannot transfer an event to yourself":
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	case "transfer is no longer pending", "transfer is no longer valid", "no event credits left":
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse(err.Error()))
+	case "transfer has expired":
+		return c.Status(fiber.StatusGone).JSON(models.ErrorResponse(err.Error()))
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+}
+
+// GetTransfer etkinliğin bekleyen sahiplik devrini döndürür
+func (h *EventTransferHandler) GetTransfer(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	transfer, err := h.transferService.GetPendingTransfer(event.ID, userID)
+	if err != nil {
+		return transferErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(transfer, "Transfer retrieved successfully"))
+}
+
+// CreateTransfer etkinliğin başka bir hesaba devrini başlatır
+func (h *EventTransferHandler) CreateTransfer(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	var req models.CreateEventTransferRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+	}
+	if err := h.validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	transfer, err := h.transferService.CreateTransfer(event.ID, userID, req)
+	if err != nil {
+		return transferErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse(transfer, "Transfer invitation sent successfully"))
+}
+
+// CancelTransfer etkinliğin bekleyen sahiplik devrini iptal eder
+func (h *EventTransferHandler) CancelTransfer(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	if err := h.transferService.CancelTransfer(event.ID, userID); err != nil {
+		return transferErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(nil, "Transfer cancelled successfully"))
+}
+
+// GetTransferPreview alıcıya devredilen etkinliğin bilgilerini döndürür
+func (h *EventTransferHandler) GetTransferPreview(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	preview, err := h.transferService.GetTransferPreview(c.Params("token"), userID)
+	if err != nil {
+		return transferErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(preview, "Transfer retrieved successfully"))
+}
+
+// AcceptTransfer devri kabul eder, etkinlik isteği yapan kullanıcının hesabına geçer
+func (h *EventTransferHandler) AcceptTransfer(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.transferService.AcceptTransfer(c.Params("token"), userID)
+	if err != nil {
+		return transferErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(h.eventService.BuildEventResponse(event), "Event transferred successfully"))
+}
+
+// DeclineTransfer devri reddeder
+func (h *EventTransferHandler) DeclineTransfer(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	if err := h.transferService.DeclineTransfer(c.Params("token"), userID); err != nil {
+		return transferErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(nil, "Transfer declined"))
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/service"
+	"github.com/sefazor/ourphotos-backend/pkg/utils"
+)
+
+// trackingPixel davet e-postalarındaki açılma takibi için 1x1 şeffaf GIF
+var trackingPixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+type GuestListHandler struct {
+	guestListService *service.GuestListService
+	eventService     *service.EventService
+	validator        *utils.Validator
+}
+
+func NewGuestListHandler(guestListService *service.GuestListService, eventService *service.EventService, validator *utils.Validator) *GuestListHandler {
+	return &GuestListHandler{
+		guestListService: guestListService,
+		eventService:     eventService,
+		validator:        validator,
+	}
+}
+
+// guestListErrorResponse davet listesi servis hatalarını HTTP durum kodlarına eşler
+func guestListErrorResponse(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "event not found", "guest not found":
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+	case "unauthorized":
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to manage this event"))
+	case "event has expired", "event has not ended yet":
+		return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse(err.Error()))
+	case "guest list is empty", "invalid csv file":
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+	if strings.HasPrefix(err.Error(), "a maximum of") {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+}
+
+func (h *GuestListHandler) GetGuests(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	guests, err := h.guestListService.GetGuests(event.ID, userID)
+	if err != nil {
+		return guestListErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(guests, "Guests retrieved successfully"))
+}
+
+// ImportGuests davet listesine misafir ekler. JSON gövdesi veya "file" alanında CSV dosyası kabul edilir.
+func (h *GuestListHandler) ImportGuests(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	var entries []models.GuestListEntry
+	if file, err := c.FormFile("file"); err == nil {
+		src, err := file.Open()
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid file"))
+		}
+		defer src.Close()
+
+		entries, err = service.ParseGuestCSV(src)
+		if err != nil {
+			return guestListErrorResponse(c, err)
+		}
+	} else {
+		var req models.ImportGuestsRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+		}
+
+		if err := h.validator.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+		}
+		entries = req.Guests
+	}
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	result, err := h.guestListService.ImportGuests(event.ID, userID, entries)
+	if err != nil {
+		return guestListErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse(result, "Guests imported successfully"))
+}
+
+func (h *GuestListHandler) DeleteGuest(c *fiber.Ctx) error {
+	guestID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid guest ID"))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	if err := h.guestListService.DeleteGuest(event.ID, uint(guestID), userID); err != nil {
+		return guestListErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(nil, "Guest removed successfully"))
+}
+
+func (h *GuestListHandler) SendInvitations(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	var req models.SendInvitationsRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+		}
+	}
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	result, err := h.guestListService.SendInvitations(event.ID, userID, req.GuestIDs)
+	if err != nil {
+		return guestListErrorResponse(c, err)
+	}
+
+	// Davetler arka planda gönderilir, sonuçlar misafir listesindeki durumlardan izlenir
+	return c.Status(fiber.StatusAccepted).JSON(models.SuccessResponse(result, "Invitations are being sent"))
+}
+
+// SendGalleryReady etkinlik sona erdikten sonra davet listesine "galeri hazır" duyurusu gönderir
+func (h *GuestListHandler) SendGalleryReady(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	result, err := h.guestListService.SendGalleryReady(event.ID, userID)
+	if err != nil {
+		return guestListErrorResponse(c, err)
+	}
+
+	// Duyurular arka planda gönderilir, sonuçlar misafir listesindeki gallery_notified_at alanından izlenir
+	return c.Status(fiber.StatusAccepted).JSON(models.SuccessResponse(result, "Gallery ready announcements are being sent"))
+}
+
+// TrackInvitationOpen davet e-postasındaki takip pikselini sunar ve açılmayı kaydeder
+func (h *GuestListHandler) TrackInvitationOpen(c *fiber.Ctx) error {
+	h.guestListService.TrackInvitationOpen(c.Params("token"))
+
+	c.Set("Content-Type", "image/gif")
+	c.Set("Cache-Control", "no-store, no-cache, must-revalidate")
+	return c.Send(trackingPixel)
+}
+
+// TrackInvitationVisit davetteki bağlantının ziyaretini kaydeder ve misafiri etkinlik sayfasına yönlendirir
+func (h *GuestListHandler) TrackInvitationVisit(c *fiber.Ctx) error {
+	eventURL, err := h.guestListService.TrackInvitationVisit(c.Params("token"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Invitation not found"))
+	}
+
+	return c.Redirect(os.Getenv("FRONTEND_URL")+"/events/"+eventURL, fiber.StatusFound)
+}
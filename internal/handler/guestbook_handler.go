@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/service"
+	"github.com/sefazor/ourphotos-backend/pkg/utils"
+)
+
+type GuestbookHandler struct {
+	guestbookService *service.GuestbookService
+	eventService     *service.EventService
+	validator        *utils.Validator
+}
+
+func NewGuestbookHandler(guestbookService *service.GuestbookService, eventService *service.EventService, validator *utils.Validator) *GuestbookHandler {
+	return &GuestbookHandler{
+		guestbookService: guestbookService,
+		eventService:     eventService,
+		validator:        validator,
+	}
+}
+
+// guestbookErrorResponse anı defteri servis hatalarını HTTP durum kodlarına eşler
+func guestbookErrorResponse(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "event not found", "entry not found", "photo not found":
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+	case "unauthorized":
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to manage this guestbook"))
+	case "guestbook is disabled for this event":
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse(err.Error()))
+	case "guest name is required", "message is required", "invalid moderation action":
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+}
+
+// GetEntries etkinliğin yayınlanmış anı defteri girdilerini sayfalı olarak döndürür
+func (h *GuestbookHandler) GetEntries(c *fiber.Ctx) error {
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return err
+	}
+
+	if denied, err := denyPublicEventAccess(c, h.eventService, event); denied {
+		return err
+	}
+
+	if event.GuestbookDisabled {
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("guestbook is disabled for this event"))
+	}
+
+	page, limit := parsePagination(c)
+	entries, total, err := h.guestbookService.ListPublicEntries(event, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(models.PaginatedResponse{
+		Items:      entries,
+		Pagination: models.NewPagination(page, limit, total),
+	}, "Guestbook retrieved successfully"))
+}
+
+func (h *GuestbookHandler) CreateEntry(c *fiber.Ctx) error {
+	var req models.CreateGuestbookEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return err
+	}
+
+	if denied, err := denyPublicEventAccess(c, h.eventService, event); denied {
+		return err
+	}
+
+	if denied, err := denyExpiredEvent(c, event); denied {
+		return err
+	}
+
+	// Giriş yapmış kullanıcı varsa al, yoksa 0 (misafir)
+	var userID uint = 0
+	if id, ok := c.Locals("userID").(uint); ok {
+		userID = id
+	}
+
+	guestID, err := ensureGuestIdentity(c, h.eventService, event)
+	if err != nil {
+		fmt.Printf("Error issuing guest token for event %s: %v\n", event.URL, err)
+	}
+
+	entry, err := h.guestbookService.CreateEntry(event, userID, guestID, req)
+	if err != nil {
+		return guestbookErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse(entry, "Guestbook entry posted successfully"))
+}
+
+// GetEventEntries etkinlik sahibine gizli ve onay bekleyenler dahil tüm girdileri döndürür
+func (h *GuestbookHandler) GetEventEntries(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	page, limit := parsePagination(c)
+	entries, total, err := h.guestbookService.ListEventEntries(event.ID, userID, page, limit)
+	if err != nil {
+		return guestbookErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(models.PaginatedResponse{
+		Items:      entries,
+		Pagination: models.NewPagination(page, limit, total),
+	}, "Guestbook retrieved successfully"))
+}
+
+func (h *GuestbookHandler) ModerateEntry(c *fiber.Ctx) error {
+	entryID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid entry ID"))
+	}
+
+	var req models.ModerateGuestbookEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	entry, err := h.guestbookService.ModerateEntry(event.ID, uint(entryID), userID, req.Action)
+	if err != nil {
+		return guestbookErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(entry, "Guestbook entry updated successfully"))
+}
+
+func (h *GuestbookHandler) DeleteEntry(c *fiber.Ctx) error {
+	entryID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid entry ID"))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	if err := h.guestbookService.DeleteEntry(event.ID, uint(entryID), userID); err != nil {
+		return guestbookErrorResponse(c, err)
+	}
+
+	return c.JSON(models.SuccessResponse(nil, "Guestbook entry deleted successfully"))
+}
+
+// ExportEntries etkinliğin anı defterini CSV dosyası olarak indirir
+func (h *GuestbookHandler) ExportEntries(c *fiber.Ctx) error {
+	url := c.Params("url")
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(url)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	data, err := h.guestbookService.ExportEntries(event.ID, userID)
+	if err != nil {
+		return guestbookErrorResponse(c, err)
+	}
+
+	c.Set("Content-Type", "text/csv; charset=utf-8")
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=event-%s-guestbook.csv", url))
+	return c.Send(data)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"bufio"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/service"
+	"github.com/sefazor/ourphotos-backend/pkg/realtime"
+	"github.com/valyala/fasthttp"
+)
+
+// liveKeepAliveInterval proxy'lerin boşta kalan bağlantıyı kapatmaması için ping aralığı
+const liveKeepAliveInterval = 25 * time.Second
+
+type LiveHandler struct {
+	eventService *service.EventService
+	feed         *realtime.Broker
+}
+
+func NewLiveHandler(eventService *service.EventService, feed *realtime.Broker) *LiveHandler {
+	return &LiveHandler{
+		eventService: eventService,
+		feed:         feed,
+	}
+}
+
+// StreamEventPhotos etkinliğe yüklenen yeni fotoğrafları Server-Sent Events ile canlı olarak iletir
+func (h *LiveHandler) StreamEventPhotos(c *fiber.Ctx) error {
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return err
+	}
+
+	if denied, err := denyPublicEventAccess(c, h.eventService, event); denied {
+		return err
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	messages, unsubscribe := h.feed.Subscribe(event.ID)
+
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		// Bağlantı koparsa tarayıcı 3 saniye sonra yeniden bağlansın
+		fmt.Fprintf(w, "retry: 3000\nevent: ready\ndata: {\"event_id\":%d}\n\n", event.ID)
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		ticker := time.NewTicker(liveKeepAliveInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case payload, ok := <-messages:
+				if !ok {
+					return
+				}
+				fmt.Fprintf(w, "event: photo\ndata: %s\n\n", payload)
+			case <-ticker.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+
+			// İstemci bağlantıyı kapattıysa Flush hata döner
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}))
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// parsePagination page ve limit query parametrelerini okur, geçersiz değerler için varsayılanları kullanır
+func parsePagination(c *fiber.Ctx) (int, int) {
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+
+	limit := c.QueryInt("limit", defaultPageLimit)
+	if limit < 1 || limit > maxPageLimit {
+		limit = defaultPageLimit
+	}
+
+	return page, limit
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/models"
 	"github.com/sefazor/ourphotos-backend/internal/service"
 	"github.com/stripe/stripe-go/v74/webhook"
 )
@@ -141,3 +142,48 @@ func (h *PaymentHandler) GetPurchaseHistory(c *fiber.Ctx) error {
 		"data":    purchases,
 	})
 }
+
+func (h *PaymentHandler) GetEventExtensionPackages(c *fiber.Ctx) error {
+	packages, err := h.paymentService.GetEventExtensionPackages()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(packages, "Extension packages retrieved successfully"))
+}
+
+// CreateEventExtensionCheckout etkinlik süresini uzatmak için Stripe ödeme oturumu oluşturur
+func (h *PaymentHandler) CreateEventExtensionCheckout(c *fiber.Ctx) error {
+	packageID, err := strconv.ParseUint(c.Params("packageId"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid package ID"))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	session, err := h.paymentService.CreateEventExtensionCheckout(userID, c.Params("url"), uint(packageID))
+	if err != nil {
+		switch err.Error() {
+		case "event not found", "extension package not found":
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		case "unauthorized":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to extend this event"))
+		case "event is already archived permanently":
+			return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(session, "Checkout session created successfully"))
+}
+
+func (h *PaymentHandler) GetEventExtensionHistory(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	purchases, err := h.paymentService.GetEventExtensionHistory(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(purchases, "Extension history retrieved successfully"))
+}
@@ -1,27 +1,38 @@
 package handler
 
 import (
-	"fmt"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sefazor/ourphotos-backend/internal/models"
 	"github.com/sefazor/ourphotos-backend/internal/service"
+	"github.com/sefazor/ourphotos-backend/pkg/utils"
 )
 
 type PhotoHandler struct {
 	photoService *service.PhotoService
 	eventService *service.EventService
+	validator    *utils.Validator
 }
 
-func NewPhotoHandler(photoService *service.PhotoService, eventService *service.EventService) *PhotoHandler {
+func NewPhotoHandler(photoService *service.PhotoService, eventService *service.EventService, validator *utils.Validator) *PhotoHandler {
 	return &PhotoHandler{
 		photoService: photoService,
 		eventService: eventService,
+		validator:    validator,
 	}
 }
 
+// albumFilter album_id query parametresini okur, geçersiz değerler için filtre uygulanmaz
+func albumFilter(c *fiber.Ctx) uint {
+	albumID := c.QueryInt("album_id", 0)
+	if albumID < 0 {
+		return 0
+	}
+	return uint(albumID)
+}
+
 func (h *PhotoHandler) GetEventPhotos(c *fiber.Ctx) error {
 	url := c.Params("url")
 
@@ -33,50 +44,36 @@ func (h *PhotoHandler) GetEventPhotos(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
 	}
 
-	photos, err := h.photoService.GetEventPhotos(event.ID, userID)
+	photos, err := h.photoService.GetEventPhotos(event.ID, userID, c.Query("sort", models.PhotoSortNewest), albumFilter(c))
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
 	}
 
-	var responses []models.PhotoResponse
-	for _, photo := range photos {
-		responses = append(responses, models.PhotoResponse{
-			ID:           photo.ID,
-			EventID:      photo.EventID,
-			UserID:       photo.UserID,
-			FileName:     photo.FileName,
-			FileSize:     photo.FileSize,
-			MimeType:     photo.MimeType,
-			PublicURL:    photo.PublicURL,
-			ThumbnailURL: h.photoService.ImgStorage.GetThumbnailURL(photo.ImageID),
-			IsGuest:      photo.IsGuest,
-			CreatedAt:    photo.CreatedAt,
-		})
+	responses, err := h.photoService.BuildPhotoResponses(photos, "")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
 	}
 
 	return c.JSON(models.SuccessResponse(responses, "Photos retrieved successfully"))
 }
 
 func (h *PhotoHandler) UploadPhoto(c *fiber.Ctx) error {
-	url := c.Params("url")
-
 	// Bu bir misafir yükleme endpoint'i olduğu için userID her zaman 0 (misafir)
 	var userID uint = 0
 
 	// URL'den etkinlik ID'sini al
-	event, err := h.eventService.GetEventByURL(url)
-	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return err
 	}
 
-	// Etkinlik misafir yüklemelerine izin veriyor mu kontrol et
-	if !event.AllowGuestUploads {
-		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("Guest uploads are not allowed for this event"))
+	if denied, err := denyUploadAccess(c, h.eventService, event); denied {
+		return err
 	}
 
-	// Etkinlik süresi dolmuş mu kontrol et
-	if time.Now().After(event.ExpiresAt) {
-		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("This event has expired"))
+	// Misafir yüklemelerine izin ve yükleme penceresi kontrolü
+	if denied, err := denyClosedUploads(c, event); denied {
+		return err
 	}
 
 	file, err := c.FormFile("file")
@@ -84,14 +81,47 @@ func (h *PhotoHandler) UploadPhoto(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("No file uploaded"))
 	}
 
-	response, err := h.photoService.UploadPhoto(event.ID, userID, file)
+	// Misafir kimliğini çöz, yoksa yenisini oluştur
+	guestID, err := ensureGuestIdentity(c, h.eventService, event)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
 	}
 
+	// Misafir isteğe bağlı olarak görünen ad, açıklama, tag ve alt text gönderebilir
+	opts := service.UploadOptions{
+		Caption:    c.FormValue("caption"),
+		AltText:    c.FormValue("alt_text"),
+		GuestID:    guestID,
+		GuestName:  c.FormValue("guest_name"),
+		UploaderIP: c.IP(),
+	}
+	if tags := c.FormValue("tags"); tags != "" {
+		opts.Tags = strings.Split(tags, ",")
+	}
+
+	response, err := h.photoService.UploadPhoto(event.ID, userID, file, opts)
+	if err != nil {
+		return uploadErrorResponse(c, err)
+	}
+
 	return c.JSON(models.SuccessResponse(response, "Photo uploaded successfully as guest"))
 }
 
+// uploadErrorResponse fotoğraf yükleme hatalarını durum ve hata koduyla birlikte yanıtlar
+func uploadErrorResponse(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "photo limit exceeded":
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponseWithCode(models.ErrCodePhotoLimitExceeded, err.Error()))
+	case "event owner's photo limit exceeded":
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponseWithCode(models.ErrCodeOwnerPhotoLimitExceeded, err.Error()))
+	case "event photo limit reached":
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponseWithCode(models.ErrCodeEventPhotoLimitReached, err.Error()))
+	case "guest photo limit reached":
+		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponseWithCode(models.ErrCodeGuestPhotoLimitReached, err.Error()))
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+}
+
 func (h *PhotoHandler) DeletePhoto(c *fiber.Ctx) error {
 	photoID, err := strconv.ParseUint(c.Params("id"), 10, 32)
 	if err != nil {
@@ -101,55 +131,221 @@ func (h *PhotoHandler) DeletePhoto(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uint)
 
 	if err := h.photoService.DeletePhoto(uint(photoID), userID); err != nil {
+		if err.Error() == "unauthorized" {
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to delete this photo"))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
 	}
 
 	return c.JSON(models.SuccessResponse(nil, "Photo deleted successfully"))
 }
 
+func (h *PhotoHandler) BulkPhotoOperation(c *fiber.Ctx) error {
+	var req models.BulkPhotoRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	result, err := h.photoService.BulkPhotoOperation(userID, req)
+	if err != nil {
+		if err.Error() == "album not found" {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(result, "Bulk operation completed"))
+}
+
 func (h *PhotoHandler) GetPublicEventPhotos(c *fiber.Ctx) error {
 	eventURL := c.Params("url")
 
 	// Önce etkinliği al
-	event, err := h.eventService.GetEventByURL(eventURL)
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return err
+	}
+
+	if denied, err := denyPublicEventAccess(c, h.eventService, event); denied {
+		return err
+	}
+
+	// Sadece public ve izin verilen etkinliklerin fotoğraflarını getir
+	photos, err := h.photoService.GetPublicEventPhotos(eventURL, c.Query("sort", models.PhotoSortNewest), albumFilter(c))
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
 	}
 
-	// Etkinlik public değilse erişimi engelle
-	if !event.IsPublic {
-		return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("This event is private"))
+	responses, err := h.photoService.BuildPhotoResponses(photos, c.Get(DeviceTokenHeader))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
 	}
 
-	// Etkinlik parola korumalıysa, cookie kontrolü yap
-	if event.HasPassword {
-		cookie := c.Cookies(fmt.Sprintf("event_%s_access", eventURL))
-		if cookie != "true" {
-			return c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse("This event requires a password"))
+	return c.JSON(models.SuccessResponse(responses, "Photos retrieved successfully"))
+}
+
+func (h *PhotoHandler) UpdatePhotoMetadata(c *fiber.Ctx) error {
+	photoID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid photo ID"))
+	}
+
+	var req models.UpdatePhotoMetadataRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	photo, err := h.photoService.UpdatePhotoMetadata(uint(photoID), userID, req)
+	if err != nil {
+		switch err.Error() {
+		case "photo not found", "event not found":
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		case "unauthorized":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to edit this photo"))
 		}
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
 	}
 
-	// Sadece public ve izin verilen etkinliklerin fotoğraflarını getir
-	photos, err := h.photoService.GetPublicEventPhotos(eventURL)
+	return c.JSON(models.SuccessResponse(photo, "Photo updated successfully"))
+}
+
+func (h *PhotoHandler) SearchPhotos(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	page, limit := parsePagination(c)
+
+	results, total, err := h.photoService.SearchPhotos(userID, c.Query("q"), page, limit)
 	if err != nil {
+		if err.Error() == "search query is required" {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
 	}
 
-	var responses []models.PhotoResponse
-	for _, photo := range photos {
-		responses = append(responses, models.PhotoResponse{
-			ID:           photo.ID,
-			EventID:      photo.EventID,
-			UserID:       photo.UserID,
-			FileName:     photo.FileName,
-			FileSize:     photo.FileSize,
-			MimeType:     photo.MimeType,
-			PublicURL:    photo.PublicURL,
-			ThumbnailURL: h.photoService.ImgStorage.GetThumbnailURL(photo.ImageID),
-			IsGuest:      photo.IsGuest,
-			CreatedAt:    photo.CreatedAt,
-		})
+	return c.JSON(models.SuccessResponse(models.PaginatedResponse{
+		Items:      results,
+		Pagination: models.NewPagination(page, limit, total),
+	}, "Photos retrieved successfully"))
+}
+
+// resolveGuest misafir endpoint'leri için etkinliği ve geçerli misafir kimliğini çözer
+func (h *PhotoHandler) resolveGuest(c *fiber.Ctx) (*models.Event, string, error) {
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return nil, "", err
+	}
+
+	if denied, err := denyLockedEvent(c, h.eventService, event); denied {
+		return nil, "", err
+	}
+
+	guestID, err := h.eventService.ResolveGuestID(event.ID, guestTokenFromRequest(c, event))
+	if err != nil {
+		return nil, "", c.Status(fiber.StatusUnauthorized).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return event, guestID, nil
+}
+
+func (h *PhotoHandler) GetMyUploads(c *fiber.Ctx) error {
+	event, guestID, err := h.resolveGuest(c)
+	if event == nil {
+		return err
+	}
+
+	photos, err := h.photoService.GetGuestUploads(event.ID, guestID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	responses, err := h.photoService.BuildPhotoResponses(photos, c.Get(DeviceTokenHeader))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
 	}
 
 	return c.JSON(models.SuccessResponse(responses, "Photos retrieved successfully"))
 }
+
+func (h *PhotoHandler) DeleteMyUpload(c *fiber.Ctx) error {
+	photoID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid photo ID"))
+	}
+
+	event, guestID, err := h.resolveGuest(c)
+	if event == nil {
+		return err
+	}
+
+	if err := h.photoService.DeleteGuestPhoto(event.ID, uint(photoID), guestID); err != nil {
+		switch err.Error() {
+		case "photo not found":
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		case "unauthorized":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You can only delete your own uploads"))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(nil, "Photo deleted successfully"))
+}
+
+func (h *PhotoHandler) UpdateMyUploadMetadata(c *fiber.Ctx) error {
+	photoID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid photo ID"))
+	}
+
+	var req models.UpdatePhotoMetadataRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+	}
+
+	event, guestID, err := h.resolveGuest(c)
+	if event == nil {
+		return err
+	}
+
+	if denied, err := denyExpiredEvent(c, event); denied {
+		return err
+	}
+
+	photo, err := h.photoService.UpdateGuestPhotoMetadata(event.ID, uint(photoID), guestID, req)
+	if err != nil {
+		switch err.Error() {
+		case "photo not found":
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		case "unauthorized":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You can only edit your own uploads"))
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(photo, "Photo updated successfully"))
+}
+
+func (h *PhotoHandler) GetUploadsByGuest(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	event, err := h.eventService.GetEventByURL(c.Params("url"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse("Event not found"))
+	}
+
+	groups, err := h.photoService.GetUploadsByGuest(event.ID, userID)
+	if err != nil {
+		if err.Error() == "unauthorized" {
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to access this event"))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(groups, "Guest uploads retrieved successfully"))
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/service"
+)
+
+type ReactionHandler struct {
+	reactionService *service.ReactionService
+	eventService    *service.EventService
+}
+
+func NewReactionHandler(reactionService *service.ReactionService, eventService *service.EventService) *ReactionHandler {
+	return &ReactionHandler{
+		reactionService: reactionService,
+		eventService:    eventService,
+	}
+}
+
+func (h *ReactionHandler) AddReaction(c *fiber.Ctx) error {
+	return h.handleReaction(c, h.reactionService.AddReaction, "Reaction added successfully")
+}
+
+func (h *ReactionHandler) RemoveReaction(c *fiber.Ctx) error {
+	return h.handleReaction(c, h.reactionService.RemoveReaction, "Reaction removed successfully")
+}
+
+func (h *ReactionHandler) handleReaction(
+	c *fiber.Ctx,
+	action func(eventID uint, photoID uint, deviceToken string) (*models.ReactionResponse, error),
+	message string,
+) error {
+	photoID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid photo ID"))
+	}
+
+	deviceToken := c.Get(DeviceTokenHeader)
+	if deviceToken == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Device token is required"))
+	}
+
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return err
+	}
+
+	if denied, err := denyPublicEventAccess(c, h.eventService, event); denied {
+		return err
+	}
+
+	if denied, err := denyExpiredEvent(c, event); denied {
+		return err
+	}
+
+	reaction, err := action(event.ID, uint(photoID), deviceToken)
+	if err != nil {
+		if err.Error() == "photo not found" {
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		}
+		if err.Error() == "invalid device token" {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(reaction, message))
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/service"
+	"github.com/sefazor/ourphotos-backend/pkg/utils"
+)
+
+type ReportHandler struct {
+	reportService *service.ReportService
+	eventService  *service.EventService
+	validator     *utils.Validator
+}
+
+func NewReportHandler(reportService *service.ReportService, eventService *service.EventService, validator *utils.Validator) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+		eventService:  eventService,
+		validator:     validator,
+	}
+}
+
+func (h *ReportHandler) ReportPhoto(c *fiber.Ctx) error {
+	photoID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid photo ID"))
+	}
+
+	var req models.CreateReportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid request body"))
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	event, err := findPublicEvent(c, h.eventService)
+	if event == nil {
+		return err
+	}
+
+	if denied, err := denyPublicEventAccess(c, h.eventService, event); denied {
+		return err
+	}
+
+	// Ziyaretçi istemcinin seçemediği IP adresi ile, aynı ağdaki cihazlar cihaz tokeni ile ayrılır
+	report, err := h.reportService.ReportPhoto(event, uint(photoID), c.IP(), c.Get(DeviceTokenHeader), req)
+	if err != nil {
+		switch err.Error() {
+		case "photo not found":
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		case "photo already reported":
+			return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse("You have already reported this photo"))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(models.SuccessResponse(fiber.Map{
+		"id":     report.ID,
+		"status": report.Status,
+	}, "Photo reported successfully"))
+}
+
+func (h *ReportHandler) ListReports(c *fiber.Ctx) error {
+	page, limit := parsePagination(c)
+
+	reports, total, err := h.reportService.ListReports(c.Query("status", models.ReportStatusOpen), page, limit)
+	if err != nil {
+		if err.Error() == "invalid report status" {
+			return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(models.PaginatedResponse{
+		Items:      reports,
+		Pagination: models.NewPagination(page, limit, total),
+	}, "Reports retrieved successfully"))
+}
+
+func (h *ReportHandler) ResolveReport(c *fiber.Ctx) error {
+	return h.closeReport(c, h.reportService.ResolveReport, "Report resolved successfully")
+}
+
+func (h *ReportHandler) DismissReport(c *fiber.Ctx) error {
+	return h.closeReport(c, h.reportService.DismissReport, "Report dismissed successfully")
+}
+
+func (h *ReportHandler) closeReport(c *fiber.Ctx, action func(reportID uint, adminID uint) error, message string) error {
+	reportID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid report ID"))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := action(uint(reportID), userID); err != nil {
+		switch err.Error() {
+		case "report not found":
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		case "report already closed":
+			return c.Status(fiber.StatusConflict).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(nil, message))
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/service"
+)
+
+type TrashHandler struct {
+	trashService *service.TrashService
+}
+
+func NewTrashHandler(trashService *service.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+func (h *TrashHandler) GetTrashedEvents(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+
+	events, err := h.trashService.ListTrashedEvents(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(events, "Deleted events retrieved successfully"))
+}
+
+func (h *TrashHandler) GetTrashedPhotos(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uint)
+	page, limit := parsePagination(c)
+
+	photos, total, err := h.trashService.ListTrashedPhotos(userID, page, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(models.PaginatedResponse{
+		Items:      photos,
+		Pagination: models.NewPagination(page, limit, total),
+	}, "Deleted photos retrieved successfully"))
+}
+
+func (h *TrashHandler) RestoreEvent(c *fiber.Ctx) error {
+	eventID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid event ID"))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.trashService.RestoreEvent(uint(eventID), userID); err != nil {
+		switch err.Error() {
+		case "event not found":
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		case "unauthorized":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to restore this event"))
+		case "event limit exceeded":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse(err.Error()))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(nil, "Event restored successfully"))
+}
+
+func (h *TrashHandler) RestorePhoto(c *fiber.Ctx) error {
+	photoID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(models.ErrorResponse("Invalid photo ID"))
+	}
+
+	userID := c.Locals("userID").(uint)
+
+	if err := h.trashService.RestorePhoto(uint(photoID), userID); err != nil {
+		switch err.Error() {
+		case "photo not found", "event not found":
+			return c.Status(fiber.StatusNotFound).JSON(models.ErrorResponse(err.Error()))
+		case "unauthorized":
+			return c.Status(fiber.StatusForbidden).JSON(models.ErrorResponse("You don't have permission to restore this photo"))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(models.ErrorResponse(err.Error()))
+	}
+
+	return c.JSON(models.SuccessResponse(nil, "Photo restored successfully"))
+}
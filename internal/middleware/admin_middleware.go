@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sefazor/ourphotos-backend/internal/repository"
+)
+
+// AdminMiddleware AuthMiddleware'den sonra çalışır ve kullanıcının admin yetkisini veritabanından kontrol eder.
+// Yetki token'a gömülmediği için admin yetkisi kaldırıldığında anında geçerli olur.
+func AdminMiddleware(userRepo *repository.UserRepository) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, ok := c.Locals("userID").(uint)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"success": false,
+				"error":   "Authentication required",
+			})
+		}
+
+		user, err := userRepo.GetByID(userID)
+		if err != nil || !user.IsAdmin {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"error":   "Admin access required",
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -62,3 +62,28 @@ func AuthMiddleware() fiber.Handler {
 		return c.Next()
 	}
 }
+
+// OptionalAuthMiddleware public endpoint'lerde geçerli bir token varsa kullanıcıyı context'e ekler,
+// token yoksa veya geçersizse isteği misafir olarak devam ettirir
+func OptionalAuthMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authHeader := c.Get("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			return c.Next()
+		}
+
+		claims, err := jwtPkg.ValidateToken(strings.TrimPrefix(authHeader, "Bearer "))
+		if err != nil {
+			return c.Next()
+		}
+
+		if userIDFloat, ok := claims["user_id"].(float64); ok {
+			c.Locals("userID", uint(userIDFloat))
+		}
+		if userEmail, ok := claims["email"].(string); ok {
+			c.Locals("userEmail", userEmail)
+		}
+
+		return c.Next()
+	}
+}
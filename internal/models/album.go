@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// PhotoAlbum etkinlik fotoğraflarını gruplamak için kullanılan albüm
+type PhotoAlbum struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	EventID   uint      `json:"event_id" gorm:"not null;index"`
+	Name      string    `json:"name" gorm:"type:varchar(100);not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateAlbumRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type AlbumResponse struct {
+	ID         uint      `json:"id"`
+	EventID    uint      `json:"event_id"`
+	Name       string    `json:"name"`
+	PhotoCount int64     `json:"photo_count"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,35 @@
+package models
+
+// Toplu fotoğraf işlemleri
+const (
+	BulkActionDelete      = "delete"
+	BulkActionHide        = "hide"
+	BulkActionUnhide      = "unhide"
+	BulkActionMoveToAlbum = "move_to_album"
+	BulkActionApprove     = "approve"
+	BulkActionSetCover    = "set_cover"
+)
+
+// MaxBulkPhotoIDs tek istekte işlenebilecek en fazla fotoğraf sayısı
+const MaxBulkPhotoIDs = 500
+
+// BulkPhotoRequest birden fazla fotoğrafa aynı işlemi uygular.
+// move_to_album için album_id gereklidir, 0 gönderilirse fotoğraflar albümden çıkarılır.
+type BulkPhotoRequest struct {
+	Action   string `json:"action" validate:"required,oneof=delete hide unhide move_to_album approve set_cover"`
+	PhotoIDs []uint `json:"photo_ids" validate:"required,min=1,max=500,dive,required"`
+	AlbumID  *uint  `json:"album_id"`
+}
+
+type BulkPhotoItemResult struct {
+	PhotoID uint   `json:"photo_id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type BulkPhotoResponse struct {
+	Action    string                `json:"action"`
+	Succeeded int                   `json:"succeeded"`
+	Failed    int                   `json:"failed"`
+	Results   []BulkPhotoItemResult `json:"results"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// PhotoComment fotoğraflara bırakılan yorumları tutar. Giriş yapmış kullanıcılar UserID ile,
+// anonim misafirler ise GuestName ile kaydedilir. Yanıtlar tek seviyeli olarak ParentID ile bağlanır.
+type PhotoComment struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	PhotoID   uint      `json:"photo_id" gorm:"not null;index"`
+	EventID   uint      `json:"event_id" gorm:"not null;index"`
+	ParentID  *uint     `json:"parent_id" gorm:"index"`
+	UserID    *uint     `json:"user_id"`
+	GuestName string    `json:"guest_name" gorm:"type:varchar(50)"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateCommentRequest struct {
+	GuestName string `json:"guest_name" validate:"max=50"`
+	Body      string `json:"body" validate:"required,max=1000"`
+	ParentID  *uint  `json:"parent_id"`
+}
+
+type CommentResponse struct {
+	ID         uint              `json:"id"`
+	PhotoID    uint              `json:"photo_id"`
+	ParentID   *uint             `json:"parent_id,omitempty"`
+	UserID     *uint             `json:"user_id,omitempty"`
+	AuthorName string            `json:"author_name"`
+	IsGuest    bool              `json:"is_guest"`
+	Body       string            `json:"body"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Replies    []CommentResponse `json:"replies,omitempty"`
+}
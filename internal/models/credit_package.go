@@ -37,3 +37,32 @@ type UserCreditPurchase struct {
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
 }
+
+// EventExtensionPackage bir etkinliğin süresini uzatmak için satın alınabilen ürün
+type EventExtensionPackage struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	Name          string    `json:"name" gorm:"not null"`
+	Description   string    `json:"description"`
+	ExtensionDays int       `json:"extension_days"`                    // Süreye eklenecek gün sayısı
+	IsPermanent   bool      `json:"is_permanent" gorm:"default:false"` // Kalıcı arşiv, etkinliğin süresi hiç dolmaz
+	Price         float64   `json:"price" gorm:"not null"`
+	IsActive      bool      `json:"is_active" gorm:"default:true"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Etkinlik süresi uzatma satın alımlarını takip etmek için
+type EventExtensionPurchase struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	UserID          uint       `json:"user_id" gorm:"not null;index"`
+	EventID         uint       `json:"event_id" gorm:"not null;index"`
+	PackageID       uint       `json:"package_id" gorm:"not null"`
+	ExtensionDays   int        `json:"extension_days"`
+	IsPermanent     bool       `json:"is_permanent" gorm:"default:false"`
+	Price           float64    `json:"price" gorm:"not null"`
+	StripeSessionID string     `json:"stripe_session_id" gorm:"unique;not null"`
+	Status          string     `json:"status" gorm:"not null;default:'pending'"`
+	NewExpiresAt    *time.Time `json:"new_expires_at"` // Ödeme tamamlandıktan sonra etkinliğin yeni bitiş tarihi
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // Duration türleri için enum tanımı
@@ -15,36 +17,87 @@ const (
 	Duration3Months DurationType = "3months"
 )
 
+// Misafir yükleme durumları
+const (
+	UploadStatusOpen      = "open"
+	UploadStatusScheduled = "scheduled" // Yükleme penceresi henüz açılmadı
+	UploadStatusClosed    = "closed"    // Yükleme penceresi kapandı, galeri görüntülenebilir
+	UploadStatusExpired   = "expired"
+	UploadStatusDisabled  = "disabled" // Etkinlik misafir yüklemelerine kapalı
+)
+
 type Event struct {
-	ID                uint      `json:"id" gorm:"primaryKey"`
-	UserID            uint      `json:"user_id" gorm:"not null"`
-	Title             string    `json:"title" gorm:"not null"`
-	Description       string    `json:"description"`
-	Location          string    `json:"location"` // Etkinlik lokasyonu
-	URL               string    `json:"url" gorm:"unique;not null"`
-	IsPublic          bool      `json:"is_public" gorm:"default:true"`
-	HasPassword       bool      `json:"has_password" gorm:"default:false"`
-	Password          string    `json:"-" gorm:"type:varchar(255)"`
-	AllowGuestUploads bool      `json:"allow_guest_uploads" gorm:"default:true"`
-	ExpiresAt         time.Time `json:"expires_at"`
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
-	PhotoCount        int       `json:"photo_count" gorm:"default:0"`
+	ID                uint           `json:"id" gorm:"primaryKey"`
+	UserID            uint           `json:"user_id" gorm:"not null"`
+	Title             string         `json:"title" gorm:"not null"`
+	Description       string         `json:"description"`
+	Location          string         `json:"location"` // Etkinlik lokasyonu
+	URL               string         `json:"url" gorm:"unique;not null"`
+	IsPublic          bool           `json:"is_public" gorm:"default:true"`
+	HasPassword       bool           `json:"has_password" gorm:"default:false"`
+	Password          string         `json:"-" gorm:"type:varchar(255)"`
+	PasswordVersion   int            `json:"-" gorm:"default:0"` // Parola her değiştiğinde artar, eski erişim tokenlerini geçersiz kılar
+	AllowGuestUploads bool           `json:"allow_guest_uploads" gorm:"default:true"`
+	CommentsDisabled  bool           `json:"comments_disabled" gorm:"default:false"`
+	GuestbookDisabled bool           `json:"guestbook_disabled" gorm:"default:false"`
+	RequireApproval   bool           `json:"require_approval" gorm:"default:false"` // Misafir yüklemeleri onaydan sonra yayınlanır
+	CoverPhotoID      *uint          `json:"cover_photo_id"`                        // Etkinlik fotoğraflarından seçilen kapak
+	CoverImageID      string         `json:"-" gorm:"type:varchar(100)"`            // Ayrıca yüklenen kapak görseli, seçilen fotoğrafa göre önceliklidir
+	AccentColor       string         `json:"accent_color" gorm:"type:varchar(9)"`   // Galeri sayfasının vurgu rengi (#RRGGBB)
+	WelcomeMessage    string         `json:"welcome_message" gorm:"type:varchar(1000)"`
+	ThankYouMessage   string         `json:"thank_you_message" gorm:"type:varchar(1000)"` // Misafir yüklemesinden sonra gösterilir
+	UploadOpensAt     *time.Time     `json:"upload_opens_at"`                             // Boşsa yüklemeler etkinlik oluşturulduğunda açılır
+	UploadClosesAt    *time.Time     `json:"upload_closes_at"`                            // Boşsa yüklemeler etkinlik süresi dolana kadar açık kalır
+	MaxPhotos         int            `json:"max_photos" gorm:"default:0"`                 // Etkinliğe yüklenebilecek toplam fotoğraf, 0 ise sınırsız
+	MaxPhotosPerGuest int            `json:"max_photos_per_guest" gorm:"default:0"`       // Bir misafirin yükleyebileceği fotoğraf, 0 ise sınırsız
+	Duration          DurationType   `json:"duration" gorm:"type:varchar(20)"`            // Oluşturma veya son güncellemede seçilen süre
+	ExpiresAt         time.Time      `json:"expires_at"`
+	IsExpired         bool           `json:"is_expired" gorm:"default:false;index"` // Süresi doldu, silinene kadar salt okunur
+	IsPermanent       bool           `json:"is_permanent" gorm:"default:false"`     // Kalıcı arşiv, ExpiresAt dikkate alınmaz
+	ExpiryWarningDays int            `json:"-" gorm:"default:0"`                    // Gönderilen en son süre uyarısının gün eşiği
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	PhotoCount        int            `json:"photo_count" gorm:"default:0"`
+	DeletedAt         gorm.DeletedAt `json:"-" gorm:"index"` // Çöp kutusundaki etkinlikler
 }
 
 type EventPasswordRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
+// EventAccessResponse parolası doğrulanan etkinlik için verilen imzalı erişim tokeni
+type EventAccessResponse struct {
+	AccessToken string    `json:"access_token"`
+	Role        string    `json:"role"` // Etkinlik parolası yükleme yetkisi verir, erişim kodları kendi rolünü taşır
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
 type EventRequest struct {
 	Title             string       `json:"title" validate:"required"`
 	Description       string       `json:"description"`
 	Location          string       `json:"location"` // Lokasyon alanı
-	HasPassword       bool         `json:"has_password"`
+	HasPassword       *bool        `json:"has_password"`
 	Password          string       `json:"password"`
-	IsPublic          bool         `json:"is_public"`
-	AllowGuestUploads bool         `json:"allow_guest_uploads"`
-	Duration          DurationType `json:"duration" validate:"required"` // ExpiresAt yerine Duration alanı
+	IsPublic          *bool        `json:"is_public"`
+	AllowGuestUploads *bool        `json:"allow_guest_uploads"`
+	CommentsDisabled  *bool        `json:"comments_disabled"`
+	GuestbookDisabled *bool        `json:"guestbook_disabled"`
+	RequireApproval   *bool        `json:"require_approval"`
+	AccentColor       string       `json:"accent_color" validate:"omitempty,hexcolor"`
+	WelcomeMessage    string       `json:"welcome_message" validate:"max=1000"`
+	ThankYouMessage   string       `json:"thank_you_message" validate:"max=1000"`
+	UploadOpensAt     *time.Time   `json:"upload_opens_at"`
+	UploadClosesAt    *time.Time   `json:"upload_closes_at"`
+	MaxPhotos         int          `json:"max_photos" validate:"min=0"`
+	MaxPhotosPerGuest int          `json:"max_photos_per_guest" validate:"min=0"`
+	Duration          DurationType `json:"duration" validate:"required_without=TemplateID"` // ExpiresAt yerine Duration alanı
+	TemplateID        *uint        `json:"template_id"`                                     // Boş bırakılan alanlar şablondan doldurulur
+}
+
+// DuplicateEventRequest etkinliğin ayarlarını fotoğraflar olmadan yeni bir etkinliğe kopyalar
+type DuplicateEventRequest struct {
+	Title    string       `json:"title"`    // Boşsa kaynak etkinliğin başlığı kullanılır
+	Duration DurationType `json:"duration"` // Boşsa kaynak etkinliğin süresi kullanılır
 }
 
 type UpdateEventRequest struct {
@@ -56,23 +109,57 @@ type UpdateEventRequest struct {
 	Password          *string       `json:"password"`
 	IsPublic          *bool         `json:"is_public"`
 	AllowGuestUploads *bool         `json:"allow_guest_uploads"`
+	CommentsDisabled  *bool         `json:"comments_disabled"`
+	GuestbookDisabled *bool         `json:"guestbook_disabled"`
+	RequireApproval   *bool         `json:"require_approval"`
+	CoverPhotoID      *uint         `json:"cover_photo_id"` // 0 gönderilirse seçili kapak fotoğrafı kaldırılır
+	AccentColor       *string       `json:"accent_color" validate:"omitempty,hexcolor"`
+	WelcomeMessage    *string       `json:"welcome_message" validate:"omitempty,max=1000"`
+	ThankYouMessage   *string       `json:"thank_you_message" validate:"omitempty,max=1000"`
+	UploadOpensAt     *time.Time    `json:"upload_opens_at"`
+	UploadClosesAt    *time.Time    `json:"upload_closes_at"`
+	ClearUploadWindow bool          `json:"clear_upload_window"` // true ise yükleme penceresi kaldırılır
+	MaxPhotos         *int          `json:"max_photos" validate:"omitempty,min=0"`
+	MaxPhotosPerGuest *int          `json:"max_photos_per_guest" validate:"omitempty,min=0"`
 	Duration          *DurationType `json:"duration"`
 }
 
 type EventResponse struct {
-	ID                      uint      `json:"id"`
-	Title                   string    `json:"title"`
-	Description             string    `json:"description"`
-	Location                string    `json:"location"` // Lokasyon bilgisi için yanıt alanı
-	URL                     string    `json:"url"`
-	IsPublic                bool      `json:"is_public"`
-	HasPassword             bool      `json:"has_password"`
-	AllowGuestUploads       bool      `json:"allow_guest_uploads"`
-	PhotoCount              int       `json:"photo_count"`
-	ExpiresAt               time.Time `json:"expires_at"`
-	Duration                string    `json:"duration"` // Kullanıcı dostu gösterim için
-	CreatedAt               time.Time `json:"created_at"`
-	UpdatedAt               time.Time `json:"updated_at"`
-	RemainingUserPhotoLimit int       `json:"remaining_user_photo_limit"`
-	TotalAllocatedPhotos    int       `json:"total_allocated_photos"`
+	ID                      uint       `json:"id"`
+	Title                   string     `json:"title"`
+	Description             string     `json:"description"`
+	Location                string     `json:"location"` // Lokasyon bilgisi için yanıt alanı
+	URL                     string     `json:"url"`
+	IsPublic                bool       `json:"is_public"`
+	HasPassword             bool       `json:"has_password"`
+	AllowGuestUploads       bool       `json:"allow_guest_uploads"`
+	CommentsDisabled        bool       `json:"comments_disabled"`
+	GuestbookDisabled       bool       `json:"guestbook_disabled"`
+	RequireApproval         bool       `json:"require_approval"`
+	CoverPhotoID            *uint      `json:"cover_photo_id"`
+	CoverImageURL           string     `json:"cover_image_url"`
+	AccentColor             string     `json:"accent_color"`
+	WelcomeMessage          string     `json:"welcome_message"`
+	ThankYouMessage         string     `json:"thank_you_message"`
+	UploadOpensAt           *time.Time `json:"upload_opens_at"`
+	UploadClosesAt          *time.Time `json:"upload_closes_at"`
+	UploadStatus            string     `json:"upload_status"`
+	MaxPhotos               int        `json:"max_photos"`
+	MaxPhotosPerGuest       int        `json:"max_photos_per_guest"`
+	PhotoCount              int        `json:"photo_count"`
+	ExpiresAt               time.Time  `json:"expires_at"`
+	IsExpired               bool       `json:"is_expired"`
+	IsPermanent             bool       `json:"is_permanent"`
+	PurgeAt                 time.Time  `json:"purge_at"` // Süresi dolan etkinliğin kalıcı olarak silineceği zaman
+	Duration                string     `json:"duration"` // Kullanıcı dostu gösterim için
+	CreatedAt               time.Time  `json:"created_at"`
+	UpdatedAt               time.Time  `json:"updated_at"`
+	RemainingUserPhotoLimit int        `json:"remaining_user_photo_limit"`
+	TotalAllocatedPhotos    int        `json:"total_allocated_photos"` // MaxPhotos, yoksa mevcut fotoğraflar + sahibin etkinlikleri arasında paylaşılan kalan limit
+}
+
+// PublicEventResponse public etkinlik sayfası için etkinlik bilgisini anı defterinin ilk sayfasıyla birlikte döner
+type PublicEventResponse struct {
+	EventResponse
+	Guestbook *PaginatedResponse `json:"guestbook,omitempty"`
 }
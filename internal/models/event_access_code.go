@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Erişim kodu rolleri
+const (
+	AccessRoleView   = "view"   // Sadece galeriyi görüntüleyebilir
+	AccessRoleUpload = "upload" // Görüntüleyebilir ve fotoğraf yükleyebilir
+)
+
+// EventAccessCode parola korumalı etkinlik için ayrı ayrı paylaşılıp iptal edilebilen erişim kodu
+type EventAccessCode struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	EventID    uint       `json:"event_id" gorm:"not null;index;index:idx_event_access_code_lookup"`
+	Label      string     `json:"label" gorm:"type:varchar(100);not null"` // Örn. "Fotoğrafçı", "Aile"
+	CodeHash   string     `json:"-" gorm:"type:varchar(255);not null"`
+	CodeLookup string     `json:"-" gorm:"type:varchar(64);not null;default:'';index:idx_event_access_code_lookup"` // Kodun anahtarlı hash'i, girilen kod bununla bulunur
+	Role       string     `json:"role" gorm:"type:varchar(20);not null"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	UsageCount int        `json:"usage_count" gorm:"default:0"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type CreateAccessCodeRequest struct {
+	Label     string     `json:"label" validate:"required,max=100"`
+	Code      string     `json:"code" validate:"omitempty,min=4,max=64"` // Boşsa rastgele kod üretilir
+	Role      string     `json:"role" validate:"required,oneof=view upload"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type UpdateAccessCodeRequest struct {
+	Label       *string    `json:"label" validate:"omitempty,max=100"`
+	Role        *string    `json:"role" validate:"omitempty,oneof=view upload"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	ClearExpiry bool       `json:"clear_expiry"` // true ise kodun son kullanma tarihi kaldırılır
+}
+
+type AccessCodeResponse struct {
+	EventAccessCode
+	Code string `json:"code,omitempty"` // Sadece oluşturulurken bir kez gösterilir
+}
@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// Ziyaret kaydı türleri
+const (
+	HitKindView     = "view"
+	HitKindDownload = "download"
+)
+
+// HitSourceQR QR kod taranarak gelinen ziyaretlerin kaynağı
+const HitSourceQR = "qr"
+
+// Analitik zaman aralıkları
+const (
+	AnalyticsIntervalHour = "hour"
+	AnalyticsIntervalDay  = "day"
+)
+
+// EventHit galeri ziyareti veya fotoğraf indirmesi gibi ham olayları tutar.
+// Tablo sadece eklemeye açıktır, raporlar arka plan işinin ürettiği EventStatsBucket kayıtlarından okunur.
+type EventHit struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	EventID   uint      `json:"event_id" gorm:"not null;index:idx_event_hit_event_created"`
+	Kind      string    `json:"kind" gorm:"type:varchar(20);not null"`
+	Source    string    `json:"source" gorm:"type:varchar(20)"`
+	VisitorID string    `json:"-" gorm:"type:varchar(64)"` // Misafir kimliği, yoksa hashlenmiş IP
+	PhotoID   *uint     `json:"photo_id"`
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_event_hit_event_created;index"`
+}
+
+// EventStatsBucket bir etkinliğin saatlik veya günlük toplanmış istatistikleri
+type EventStatsBucket struct {
+	ID             uint      `json:"-" gorm:"primaryKey"`
+	EventID        uint      `json:"-" gorm:"not null;uniqueIndex:idx_event_stats_bucket"`
+	Period         string    `json:"-" gorm:"type:varchar(10);not null;uniqueIndex:idx_event_stats_bucket"` // hour veya day
+	BucketStart    time.Time `json:"bucket_start" gorm:"not null;uniqueIndex:idx_event_stats_bucket"`
+	Views          int64     `json:"views"`
+	UniqueVisitors int64     `json:"unique_visitors"`
+	QRScans        int64     `json:"qr_scans"`
+	Downloads      int64     `json:"downloads"`
+	GuestUploads   int64     `json:"guest_uploads"`
+	OwnerUploads   int64     `json:"owner_uploads"`
+	UpdatedAt      time.Time `json:"-"`
+}
+
+// AnalyticsTotals seçilen tarih aralığındaki toplam değerler
+type AnalyticsTotals struct {
+	Views          int64 `json:"views"`
+	UniqueVisitors int64 `json:"unique_visitors"`
+	QRScans        int64 `json:"qr_scans"`
+	Downloads      int64 `json:"downloads"`
+	Uploads        int64 `json:"uploads"`
+	GuestUploads   int64 `json:"guest_uploads"`
+	OwnerUploads   int64 `json:"owner_uploads"`
+}
+
+// TopUploader en çok fotoğraf yükleyen kişiler
+type TopUploader struct {
+	Name       string `json:"name"`
+	IsGuest    bool   `json:"is_guest"`
+	PhotoCount int64  `json:"photo_count"`
+}
+
+// EventAnalyticsResponse etkinlik analitik panelinin verileri
+type EventAnalyticsResponse struct {
+	From         time.Time          `json:"from"`
+	To           time.Time          `json:"to"`
+	Interval     string             `json:"interval"`
+	Totals       AnalyticsTotals    `json:"totals"`
+	Series       []EventStatsBucket `json:"series"`
+	TopUploaders []TopUploader      `json:"top_uploaders"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Davetli durumları. Durum sadece ileri doğru ilerler (delivered -> opened -> visited).
+const (
+	GuestStatusPending   = "pending"   // Listeye eklendi, davet gönderilmedi
+	GuestStatusQueued    = "queued"    // Davet e-postası gönderim kuyruğunda
+	GuestStatusFailed    = "failed"    // Davet e-postası gönderilemedi
+	GuestStatusDelivered = "delivered" // Davet e-postası e-posta servisine teslim edildi
+	GuestStatusOpened    = "opened"    // Davet e-postası açıldı
+	GuestStatusVisited   = "visited"   // Davetteki bağlantıdan etkinlik sayfası ziyaret edildi
+)
+
+// EventGuest etkinlik sahibinin davet listesindeki misafir
+type EventGuest struct {
+	ID                uint       `json:"id" gorm:"primaryKey"`
+	EventID           uint       `json:"event_id" gorm:"not null;uniqueIndex:idx_event_guest_email"`
+	Email             string     `json:"email" gorm:"type:varchar(255);not null;uniqueIndex:idx_event_guest_email"`
+	Name              string     `json:"name" gorm:"type:varchar(100)"`
+	InviteToken       string     `json:"-" gorm:"type:varchar(36);uniqueIndex;not null"` // Açılma ve ziyaret takibi için davet bağlantısındaki token
+	AccessCodeID      *uint      `json:"access_code_id"`                                 // Parola korumalı etkinlikte misafire özel erişim kodu
+	Status            string     `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	InvitedAt         *time.Time `json:"invited_at"`
+	OpenedAt          *time.Time `json:"opened_at"`
+	VisitedAt         *time.Time `json:"visited_at"`
+	GalleryQueuedAt   *time.Time `json:"-"`                   // "Galeri hazır" duyurusunun gönderim kuyruğuna alındığı zaman
+	GalleryNotifiedAt *time.Time `json:"gallery_notified_at"` // "Galeri hazır" duyurusunun gönderildiği zaman
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// GuestListEntry içe aktarılan misafir. Geçersiz e-posta adresleri isteği reddetmez, yanıtta raporlanır.
+type GuestListEntry struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// ImportGuestsRequest JSON ile davetli listesi içe aktarma isteği. CSV için "file" alanıyla multipart form kullanılır.
+type ImportGuestsRequest struct {
+	Guests []GuestListEntry `json:"guests" validate:"required,min=1"`
+}
+
+type ImportGuestsResponse struct {
+	Imported int      `json:"imported"`
+	Skipped  int      `json:"skipped"` // Listede zaten bulunan adresler
+	Invalid  []string `json:"invalid"` // Geçersiz e-posta adresleri
+}
+
+// SendInvitationsRequest boş GuestIDs ile henüz davet edilmemiş veya gönderimi başarısız olan tüm misafirlere davet gönderir
+type SendInvitationsRequest struct {
+	GuestIDs []uint `json:"guest_ids"`
+}
+
+// GuestEmailQueueResult arka planda gönderilmek üzere kuyruğa alınan misafir e-postalarının sayısı
+type GuestEmailQueueResult struct {
+	Queued int `json:"queued"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// Etkinlik listesi durum filtreleri
+const (
+	EventStatusActive  = "active"
+	EventStatusExpired = "expired"
+)
+
+// Etkinlik listesi sıralama alanları
+const (
+	EventSortCreated = "created"
+	EventSortExpiry  = "expiry"
+	EventSortPhotos  = "photos"
+)
+
+// EventListQuery etkinlik sahibinin etkinlik listesi için arama, filtre ve sıralama ayarları
+type EventListQuery struct {
+	Page              int
+	Limit             int
+	Search            string // Başlık veya lokasyonda aranır
+	Status            string // active, expired veya boş (hepsi)
+	PasswordProtected bool   // Sadece parola korumalı etkinlikler
+	PendingModeration bool   // Sadece onay bekleyen fotoğraf veya anı defteri girdisi olan etkinlikler
+	Sort              string // created, expiry veya photos
+	Order             string // asc veya desc
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// EventPasswordAttempt etkinlik parolası için başarısız deneme sayacı. Gecikme ve kilit
+// denemeyi yapan istemciye (hashlenmiş IP) göre tutulur, IPHash boş olan kayıt etkinlik
+// genelindeki sayaçtır. Sunucular arasında paylaşılması ve yeniden başlatmada korunması
+// için veritabanında tutulur.
+type EventPasswordAttempt struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	EventID         uint       `json:"event_id" gorm:"uniqueIndex:idx_event_password_attempt_client"`
+	IPHash          string     `json:"-" gorm:"type:varchar(64);not null;default:'';uniqueIndex:idx_event_password_attempt_client"`
+	FailedCount     int        `json:"failed_count" gorm:"default:0"` // Deneme penceresindeki başarısız deneme sayısı, sonucu beklenen denemeler dahil
+	LastFailedAt    time.Time  `json:"last_failed_at"`
+	LockedUntil     *time.Time `json:"locked_until"` // Bu zamana kadar parola denemesi kabul edilmez
+	OwnerNotifiedAt *time.Time `json:"owner_notified_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// Baskı düzenleri
+const (
+	PrintLayoutA4     = "a4"
+	PrintLayoutA5     = "a5"
+	PrintLayoutLetter = "letter"
+	PrintLayoutCard   = "card" // 4x6 inç masa kartı
+)
+
+// MaxPrintCopies tek bir PDF'te üretilebilecek en fazla masa kartı sayısı
+const MaxPrintCopies = 100
+
+// PrintOptions etkinlik afişi veya masa kartı PDF'inin düzen ayarları
+type PrintOptions struct {
+	Layout string // a4, a5, letter veya card
+	Sheet  string // Masa kartlarının dizileceği kağıt (a4 veya letter), boşsa her kart ayrı sayfadır
+	Copies int    // Üretilecek masa kartı sayısı
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Kalıcı silme nedenleri
+const (
+	PurgeReasonExpired = "expired" // Süre ve ek süre doldu
+	PurgeReasonTrash   = "trash"   // Çöp kutusunda saklama süresi doldu
+)
+
+// EventPurgeLog kalıcı olarak silinen etkinliklerin denetim kaydı
+type EventPurgeLog struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	EventID    uint      `json:"event_id" gorm:"index"`
+	UserID     uint      `json:"user_id" gorm:"index"`
+	Title      string    `json:"title"`
+	URL        string    `json:"url" gorm:"type:varchar(100)"`
+	PhotoCount int       `json:"photo_count"` // Depolamadan silinen fotoğraf sayısı
+	Reason     string    `json:"reason" gorm:"type:varchar(20)"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	PurgedAt   time.Time `json:"purged_at"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// EventTemplate kullanıcının sık kullandığı etkinlik ayarlarını saklar
+type EventTemplate struct {
+	ID                uint         `json:"id" gorm:"primaryKey"`
+	UserID            uint         `json:"user_id" gorm:"not null;index"`
+	Name              string       `json:"name" gorm:"type:varchar(100);not null"`
+	Description       string       `json:"description"`
+	Location          string       `json:"location"`
+	IsPublic          bool         `json:"is_public" gorm:"default:false"`
+	HasPassword       bool         `json:"has_password" gorm:"default:false"`
+	Password          string       `json:"-" gorm:"type:varchar(255)"` // Şablondan oluşturulan etkinliklere kopyalanan hash
+	AllowGuestUploads bool         `json:"allow_guest_uploads" gorm:"default:false"`
+	CommentsDisabled  bool         `json:"comments_disabled" gorm:"default:false"`
+	GuestbookDisabled bool         `json:"guestbook_disabled" gorm:"default:false"`
+	RequireApproval   bool         `json:"require_approval" gorm:"default:false"`
+	AccentColor       string       `json:"accent_color" gorm:"type:varchar(9)"`
+	WelcomeMessage    string       `json:"welcome_message" gorm:"type:varchar(1000)"`
+	ThankYouMessage   string       `json:"thank_you_message" gorm:"type:varchar(1000)"`
+	MaxPhotos         int          `json:"max_photos" gorm:"default:0"`
+	MaxPhotosPerGuest int          `json:"max_photos_per_guest" gorm:"default:0"`
+	Duration          DurationType `json:"duration" gorm:"type:varchar(20)"`
+	CreatedAt         time.Time    `json:"created_at"`
+	UpdatedAt         time.Time    `json:"updated_at"`
+}
+
+type EventTemplateRequest struct {
+	Name              string       `json:"name" validate:"required,max=100"`
+	Description       string       `json:"description"`
+	Location          string       `json:"location"`
+	IsPublic          bool         `json:"is_public"`
+	HasPassword       bool         `json:"has_password"`
+	Password          string       `json:"password"`
+	AllowGuestUploads bool         `json:"allow_guest_uploads"`
+	CommentsDisabled  bool         `json:"comments_disabled"`
+	GuestbookDisabled bool         `json:"guestbook_disabled"`
+	RequireApproval   bool         `json:"require_approval"`
+	AccentColor       string       `json:"accent_color" validate:"omitempty,hexcolor"`
+	WelcomeMessage    string       `json:"welcome_message" validate:"max=1000"`
+	ThankYouMessage   string       `json:"thank_you_message" validate:"max=1000"`
+	MaxPhotos         int          `json:"max_photos" validate:"min=0"`
+	MaxPhotosPerGuest int          `json:"max_photos_per_guest" validate:"min=0"`
+	Duration          DurationType `json:"duration" validate:"required"`
+}
+
+// SaveEventTemplateRequest mevcut bir etkinliğin ayarlarını şablon olarak kaydeder
+type SaveEventTemplateRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
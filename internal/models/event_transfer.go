@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Sahiplik devri durumları
+const (
+	TransferStatusPending   = "pending"   // Alıcının yanıtı bekleniyor
+	TransferStatusAccepted  = "accepted"  // Alıcı kabul etti, etkinlik alıcıya geçti
+	TransferStatusDeclined  = "declined"  // Alıcı reddetti
+	TransferStatusCancelled = "cancelled" // Etkinlik sahibi iptal etti veya yeni bir devir başlattı
+)
+
+// EventTransfer etkinliğin başka bir hesaba devredilmesi için gönderilen davet.
+// Alıcı e-postadaki bağlantıyla, aynı e-posta adresine sahip hesabıyla kabul eder.
+type EventTransfer struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	EventID     uint       `json:"event_id" gorm:"not null;index"`
+	FromUserID  uint       `json:"from_user_id" gorm:"not null;index"`
+	ToEmail     string     `json:"to_email" gorm:"type:varchar(255);not null"`
+	ToUserID    *uint      `json:"to_user_id"` // Kabul eden hesap
+	Token       string     `json:"-" gorm:"type:varchar(36);uniqueIndex;not null"`
+	Status      string     `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RespondedAt *time.Time `json:"responded_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type CreateEventTransferRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// EventTransferPreviewResponse alıcının kabul etmeden önce gördüğü devir bilgileri
+type EventTransferPreviewResponse struct {
+	EventTitle string    `json:"event_title"`
+	EventURL   string    `json:"event_url"`
+	PhotoCount int       `json:"photo_count"`
+	FromName   string    `json:"from_name"`
+	ToEmail    string    `json:"to_email"`
+	Status     string    `json:"status"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
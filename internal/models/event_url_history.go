@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// EventURLHistory etkinliğin daha önce kullandığı URL'leri tutar.
+// Eski linkler ve basılmış QR kodlar bu kayıtlar üzerinden yeni URL'ye yönlendirilir.
+type EventURLHistory struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	EventID   uint      `json:"event_id" gorm:"not null;index"`
+	URL       string    `json:"url" gorm:"type:varchar(100);uniqueIndex;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+// GuestTokenResponse misafire verilen anonim kimlik tokeni
+type GuestTokenResponse struct {
+	GuestID    string `json:"guest_id"`
+	GuestToken string `json:"guest_token"`
+}
+
+// GuestUploadGroup etkinlik sahibine misafir bazında gruplanmış yüklemeleri gösterir
+type GuestUploadGroup struct {
+	GuestID    string          `json:"guest_id"`
+	GuestName  string          `json:"guest_name"`
+	PhotoCount int             `json:"photo_count"`
+	Photos     []PhotoResponse `json:"photos"`
+}
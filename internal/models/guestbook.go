@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Anı defteri moderasyon işlemleri
+const (
+	GuestbookActionHide    = "hide"
+	GuestbookActionUnhide  = "unhide"
+	GuestbookActionApprove = "approve"
+)
+
+// GuestbookEntry misafirlerin etkinliğe bıraktığı yazılı dilekleri tutar. Giriş yapmış kullanıcılar
+// UserID ile, anonim misafirler GuestName ile kaydedilir. İstenirse etkinlikteki bir fotoğrafa bağlanabilir.
+type GuestbookEntry struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	EventID         uint      `json:"event_id" gorm:"not null;index"`
+	UserID          *uint     `json:"user_id"`
+	GuestID         string    `json:"-" gorm:"type:varchar(36);index"`
+	GuestName       string    `json:"guest_name" gorm:"type:varchar(50)"`
+	Message         string    `json:"message" gorm:"type:text;not null"`
+	PhotoID         *uint     `json:"photo_id"`
+	IsHidden        bool      `json:"is_hidden" gorm:"default:false"`        // Etkinlik sahibinin gizlediği girdiler public akışta görünmez
+	PendingApproval bool      `json:"pending_approval" gorm:"default:false"` // Onay gerektiren etkinliklerde misafir girdileri onaydan sonra yayınlanır
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type CreateGuestbookEntryRequest struct {
+	GuestName string `json:"guest_name" validate:"max=50"`
+	Message   string `json:"message" validate:"required,max=2000"`
+	PhotoID   *uint  `json:"photo_id"`
+}
+
+type ModerateGuestbookEntryRequest struct {
+	Action string `json:"action" validate:"required,oneof=hide unhide approve"`
+}
+
+type GuestbookEntryResponse struct {
+	ID              uint      `json:"id"`
+	UserID          *uint     `json:"user_id,omitempty"`
+	AuthorName      string    `json:"author_name"`
+	IsGuest         bool      `json:"is_guest"`
+	Message         string    `json:"message"`
+	PhotoID         *uint     `json:"photo_id,omitempty"`
+	PhotoURL        string    `json:"photo_url,omitempty"`
+	IsHidden        bool      `json:"is_hidden,omitempty"`
+	PendingApproval bool      `json:"pending_approval,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
@@ -1,22 +1,73 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
+
+	"gorm.io/gorm"
 )
 
+// StringList Postgres'te jsonb olarak saklanan string listesi
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (l *StringList) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*l = StringList{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.New("unsupported type for StringList")
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
 type Photos struct {
-	ID         uint      `gorm:"primaryKey" json:"id"`
-	EventID    uint      `json:"event_id"`
-	UserID     uint      `json:"user_id"`
-	FileName   string    `json:"file_name"`
-	FileSize   int64     `json:"file_size"`
-	MimeType   string    `json:"mime_type"`
-	ImageID    string    `json:"image_id"`
-	PublicURL  string    `json:"public_url"`
-	IsGuest    bool      `json:"is_guest"`
-	UploadedAt time.Time `json:"uploaded_at"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	EventID         uint           `json:"event_id"`
+	UserID          uint           `json:"user_id"`
+	FileName        string         `json:"file_name"`
+	FileSize        int64          `json:"file_size"`
+	MimeType        string         `json:"mime_type"`
+	ImageID         string         `json:"image_id"`
+	PublicURL       string         `json:"public_url"`
+	IsGuest         bool           `json:"is_guest"`
+	GuestID         string         `json:"-" gorm:"type:varchar(36);index"`
+	GuestName       string         `json:"guest_name" gorm:"type:varchar(50)"`
+	UploaderIPHash  string         `json:"-" gorm:"type:varchar(64);index"` // Misafir başına yükleme sınırı için hashlenmiş IP
+	Caption         string         `json:"caption" gorm:"type:varchar(500)"`
+	Tags            StringList     `json:"tags" gorm:"type:jsonb;default:'[]'"`
+	AltText         string         `json:"alt_text" gorm:"type:varchar(300)"`
+	IsHidden        bool           `json:"is_hidden" gorm:"default:false"`        // Şikayet/moderasyon sonrası public galeriden gizlenen fotoğraflar
+	OwnerHidden     bool           `json:"owner_hidden" gorm:"default:false"`     // Etkinlik sahibinin kendi gizlediği fotoğraflar
+	PendingApproval bool           `json:"pending_approval" gorm:"default:false"` // Onay bekleyen misafir yüklemeleri galeride görünmez
+	AlbumID         *uint          `json:"album_id" gorm:"index"`
+	UploadedAt      time.Time      `json:"uploaded_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `json:"-" gorm:"index"` // Çöp kutusundaki fotoğraflar
+}
+
+// Hidden fotoğraf moderasyon ya da etkinlik sahibi tarafından gizlendiyse true döner
+func (p *Photos) Hidden() bool {
+	return p.IsHidden || p.OwnerHidden
 }
 
 type CreatePhotoRequest struct {
@@ -24,15 +75,50 @@ type CreatePhotoRequest struct {
 	File    []byte `json:"-" validate:"required"` // Form-data'dan gelecek
 }
 
+type UpdatePhotoMetadataRequest struct {
+	Caption *string   `json:"caption"`
+	Tags    *[]string `json:"tags"`
+	AltText *string   `json:"alt_text"`
+}
+
 type PhotoResponse struct {
-	ID           uint      `json:"id"`
-	EventID      uint      `json:"event_id"`
-	UserID       uint      `json:"user_id,omitempty"`
-	FileName     string    `json:"file_name"`
-	FileSize     int64     `json:"file_size"`
-	MimeType     string    `json:"mime_type"`
-	PublicURL    string    `json:"public_url"`
-	ThumbnailURL string    `json:"thumbnail_url"`
-	IsGuest      bool      `json:"is_guest"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID              uint       `json:"id"`
+	EventID         uint       `json:"event_id"`
+	UserID          uint       `json:"user_id,omitempty"`
+	FileName        string     `json:"file_name"`
+	FileSize        int64      `json:"file_size"`
+	MimeType        string     `json:"mime_type"`
+	PublicURL       string     `json:"public_url"`
+	ThumbnailURL    string     `json:"thumbnail_url"`
+	IsGuest         bool       `json:"is_guest"`
+	GuestName       string     `json:"guest_name,omitempty"`
+	Caption         string     `json:"caption"`
+	Tags            StringList `json:"tags"`
+	AltText         string     `json:"alt_text"`
+	IsHidden        bool       `json:"is_hidden,omitempty"`
+	OwnerHidden     bool       `json:"owner_hidden,omitempty"`
+	PendingApproval bool       `json:"pending_approval,omitempty"`
+	AlbumID         *uint      `json:"album_id,omitempty"`
+	LikeCount       int64      `json:"like_count"`
+	LikedByMe       bool       `json:"liked_by_me,omitempty"`
+	CommentCount    int64      `json:"comment_count"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// PhotoSearchResult arama sonucunda dönen fotoğrafı bulunduğu etkinlik bilgisiyle birlikte taşır
+type PhotoSearchResult struct {
+	PhotoResponse
+	EventURL   string `json:"event_url"`
+	EventTitle string `json:"event_title"`
+}
+
+// Canlı fotoğraf akışı mesaj türleri
+const (
+	PhotoFeedCreated = "photo.created"
+)
+
+// PhotoFeedMessage canlı slayt gösterisine gönderilen mesaj
+type PhotoFeedMessage struct {
+	Type  string        `json:"type"`
+	Photo PhotoResponse `json:"photo"`
 }
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Reaksiyon türleri
+const (
+	ReactionHeart = "heart"
+)
+
+// Fotoğraf sıralama seçenekleri
+const (
+	PhotoSortNewest    = "newest"
+	PhotoSortMostLiked = "most_liked"
+)
+
+// PhotoReaction misafirlerin fotoğraflara bıraktığı anonim reaksiyonları tutar.
+// Aynı cihaz bir fotoğrafa aynı reaksiyonu sadece bir kez verebilir.
+type PhotoReaction struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	PhotoID     uint      `json:"photo_id" gorm:"not null;uniqueIndex:idx_photo_reaction_device"`
+	EventID     uint      `json:"event_id" gorm:"not null;index"`
+	Type        string    `json:"type" gorm:"type:varchar(20);not null;default:'heart';uniqueIndex:idx_photo_reaction_device"`
+	DeviceToken string    `json:"-" gorm:"type:varchar(64);not null;uniqueIndex:idx_photo_reaction_device"` // SHA-256 hash
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ReactionResponse struct {
+	PhotoID   uint  `json:"photo_id"`
+	LikeCount int64 `json:"like_count"`
+	LikedByMe bool  `json:"liked_by_me"`
+}
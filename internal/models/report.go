@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Şikayet nedenleri
+const (
+	ReportReasonIllegal   = "illegal"
+	ReportReasonOffensive = "offensive"
+	ReportReasonPrivacy   = "privacy"
+	ReportReasonSpam      = "spam"
+	ReportReasonOther     = "other"
+)
+
+// Şikayet durumları
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+// PhotoReport public galerideki bir fotoğraf için yapılan şikayeti tutar.
+// Aynı ziyaretçi (IP ve cihaz) bir fotoğrafı sadece bir kez şikayet edebilir.
+type PhotoReport struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	PhotoID      uint       `json:"photo_id" gorm:"not null;uniqueIndex:idx_photo_report_reporter"`
+	EventID      uint       `json:"event_id" gorm:"not null;index"`
+	Reason       string     `json:"reason" gorm:"type:varchar(20);not null"`
+	Message      string     `json:"message" gorm:"type:varchar(1000)"`
+	ReporterHash string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex:idx_photo_report_reporter"`
+	ReporterIP   string     `json:"-" gorm:"type:varchar(64);index"` // Hashlenmiş IP, gizleme eşiği farklı IP sayısına göre hesaplanır
+	Status       string     `json:"status" gorm:"type:varchar(20);not null;default:'open';index"`
+	ResolvedBy   *uint      `json:"resolved_by"`
+	ResolvedAt   *time.Time `json:"resolved_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+type CreateReportRequest struct {
+	Reason  string `json:"reason" validate:"required,oneof=illegal offensive privacy spam other"`
+	Message string `json:"message" validate:"max=1000"`
+}
+
+type ReportResponse struct {
+	ID              uint       `json:"id"`
+	PhotoID         uint       `json:"photo_id"`
+	EventID         uint       `json:"event_id"`
+	EventURL        string     `json:"event_url"`
+	EventTitle      string     `json:"event_title"`
+	PhotoURL        string     `json:"photo_url"`
+	ThumbnailURL    string     `json:"thumbnail_url"`
+	PhotoHidden     bool       `json:"photo_hidden"`
+	OpenReportCount int64      `json:"open_report_count"`
+	Reason          string     `json:"reason"`
+	Message         string     `json:"message"`
+	Status          string     `json:"status"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
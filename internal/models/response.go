@@ -4,6 +4,7 @@ type Response struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	Code    string      `json:"code,omitempty"` // İstemcinin hatayı ayırt edebilmesi için makine tarafından okunabilir kod
 	Data    interface{} `json:"data,omitempty"`
 }
 
@@ -23,3 +24,46 @@ func ErrorResponse(err string) Response {
 		Error:   err,
 	}
 }
+
+// Kodlu hata response'u için helper
+func ErrorResponseWithCode(code string, err string) Response {
+	return Response{
+		Success: false,
+		Error:   err,
+		Code:    code,
+	}
+}
+
+// Hata kodları
+const (
+	ErrCodePhotoLimitExceeded      = "photo_limit_exceeded"
+	ErrCodeOwnerPhotoLimitExceeded = "owner_photo_limit_exceeded"
+	ErrCodeEventPhotoLimitReached  = "event_photo_limit_reached"
+	ErrCodeGuestPhotoLimitReached  = "guest_photo_limit_reached"
+)
+
+// Sayfalı listeler için meta bilgisi
+type Pagination struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+}
+
+type PaginatedResponse struct {
+	Items      interface{} `json:"items"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+func NewPagination(page, limit int, total int64) Pagination {
+	totalPages := 0
+	if limit > 0 {
+		totalPages = int((total + int64(limit) - 1) / int64(limit))
+	}
+	return Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
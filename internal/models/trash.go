@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// TrashedEventResponse çöp kutusundaki etkinliği ve kalıcı olarak silineceği zamanı gösterir
+type TrashedEventResponse struct {
+	ID         uint      `json:"id"`
+	Title      string    `json:"title"`
+	URL        string    `json:"url"`
+	PhotoCount int       `json:"photo_count"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	PurgeAt    time.Time `json:"purge_at"`
+}
+
+// TrashedPhotoResponse çöp kutusundaki fotoğrafı bulunduğu etkinlik bilgisiyle birlikte gösterir
+type TrashedPhotoResponse struct {
+	PhotoResponse
+	EventURL   string    `json:"event_url"`
+	EventTitle string    `json:"event_title"`
+	DeletedAt  time.Time `json:"deleted_at"`
+	PurgeAt    time.Time `json:"purge_at"`
+}
@@ -12,6 +12,7 @@ type User struct {
 	EventLimit int       `json:"event_limit" gorm:"default:1"`
 	PhotoLimit int       `json:"photo_limit" gorm:"default:20"`
 	IsVerified bool      `json:"is_verified" gorm:"default:false"`
+	IsAdmin    bool      `json:"is_admin" gorm:"default:false"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type AccessCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewAccessCodeRepository(db *gorm.DB) *AccessCodeRepository {
+	return &AccessCodeRepository{
+		db: db,
+	}
+}
+
+func (r *AccessCodeRepository) Create(code *models.EventAccessCode) error {
+	return r.db.Create(code).Error
+}
+
+func (r *AccessCodeRepository) GetByID(id uint) (*models.EventAccessCode, error) {
+	var code models.EventAccessCode
+	err := r.db.First(&code, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *AccessCodeRepository) GetByEventID(eventID uint) ([]models.EventAccessCode, error) {
+	var codes []models.EventAccessCode
+	err := r.db.Where("event_id = ?", eventID).Order("created_at ASC").Find(&codes).Error
+	return codes, err
+}
+
+// GetActiveByLookup etkinliğin anahtarlı hash'i eşleşen, süresi dolmamış erişim kodunu döndürür
+func (r *AccessCodeRepository) GetActiveByLookup(eventID uint, lookup string, now time.Time) (*models.EventAccessCode, error) {
+	var code models.EventAccessCode
+	err := r.db.Where("event_id = ? AND code_lookup = ? AND (expires_at IS NULL OR expires_at > ?)", eventID, lookup, now).
+		First(&code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *AccessCodeRepository) CountByEventID(eventID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.EventAccessCode{}).Where("event_id = ?", eventID).Count(&count).Error
+	return count, err
+}
+
+func (r *AccessCodeRepository) Update(code *models.EventAccessCode) error {
+	return r.db.Save(code).Error
+}
+
+// RecordUsage kodun kullanım sayacını atomik olarak artırır
+func (r *AccessCodeRepository) RecordUsage(id uint, now time.Time) error {
+	return r.db.Model(&models.EventAccessCode{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"usage_count":  gorm.Expr("usage_count + 1"),
+		"last_used_at": now,
+	}).Error
+}
+
+func (r *AccessCodeRepository) Delete(id uint) error {
+	return r.db.Delete(&models.EventAccessCode{}, id).Error
+}
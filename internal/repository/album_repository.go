@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type AlbumRepository struct {
+	db *gorm.DB
+}
+
+func NewAlbumRepository(db *gorm.DB) *AlbumRepository {
+	return &AlbumRepository{
+		db: db,
+	}
+}
+
+func (r *AlbumRepository) Create(album *models.PhotoAlbum) error {
+	return r.db.Create(album).Error
+}
+
+func (r *AlbumRepository) GetByID(id uint) (*models.PhotoAlbum, error) {
+	var album models.PhotoAlbum
+	err := r.db.First(&album, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &album, nil
+}
+
+func (r *AlbumRepository) GetByEventID(eventID uint) ([]models.PhotoAlbum, error) {
+	var albums []models.PhotoAlbum
+	err := r.db.Where("event_id = ?", eventID).
+		Order("created_at ASC").
+		Find(&albums).Error
+	return albums, err
+}
+
+// Delete albümü siler ve çöp kutusundakiler dahil içindeki fotoğrafları albümsüz bırakır
+func (r *AlbumRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Photos{}).Where("album_id = ?", id).Update("album_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.PhotoAlbum{}, id).Error
+	})
+}
+
+func (r *AlbumRepository) DeleteByEventID(eventID uint) error {
+	return r.db.Where("event_id = ?", eventID).Delete(&models.PhotoAlbum{}).Error
+}
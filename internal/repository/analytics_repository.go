@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AnalyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository(db *gorm.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{
+		db: db,
+	}
+}
+
+// UploaderCount bir yükleyicinin fotoğraf sayısı
+type UploaderCount struct {
+	IsGuest   bool
+	UserID    uint
+	GuestName string
+	Count     int64
+}
+
+func (r *AnalyticsRepository) CreateHit(hit *models.EventHit) error {
+	return r.db.Create(hit).Error
+}
+
+// AggregateHits since anından itibaren kaydedilen ziyaretleri etkinlik ve zaman dilimine göre toplar.
+// Zaman dilimleri UTC'ye göre hesaplanır.
+func (r *AnalyticsRepository) AggregateHits(period string, since time.Time) ([]models.EventStatsBucket, error) {
+	var buckets []models.EventStatsBucket
+	err := r.db.Model(&models.EventHit{}).
+		Select(`event_id, date_trunc(?, created_at AT TIME ZONE 'UTC') AS bucket_start,
+			COUNT(*) FILTER (WHERE kind = ?) AS views,
+			COUNT(DISTINCT visitor_id) FILTER (WHERE kind = ?) AS unique_visitors,
+			COUNT(*) FILTER (WHERE kind = ? AND source = ?) AS qr_scans,
+			COUNT(*) FILTER (WHERE kind = ?) AS downloads`,
+			period, models.HitKindView, models.HitKindView, models.HitKindView, models.HitSourceQR, models.HitKindDownload).
+		Where("created_at >= ?", since).
+		Group("event_id, bucket_start").
+		Scan(&buckets).Error
+	return buckets, err
+}
+
+// AggregateUploads since anından itibaren yüklenen fotoğrafları etkinlik ve zaman dilimine göre toplar.
+// Sonradan silinen fotoğraflar da yükleme olarak sayılır.
+func (r *AnalyticsRepository) AggregateUploads(period string, since time.Time) ([]models.EventStatsBucket, error) {
+	var buckets []models.EventStatsBucket
+	err := r.db.Unscoped().Model(&models.Photos{}).
+		Select(`event_id, date_trunc(?, created_at AT TIME ZONE 'UTC') AS bucket_start,
+			COUNT(*) FILTER (WHERE is_guest) AS guest_uploads,
+			COUNT(*) FILTER (WHERE NOT is_guest) AS owner_uploads`, period).
+		Where("created_at >= ?", since).
+		Group("event_id, bucket_start").
+		Scan(&buckets).Error
+	return buckets, err
+}
+
+// UpsertBuckets toplanmış istatistikleri kaydeder, aynı zaman dilimi varsa değerlerini günceller
+func (r *AnalyticsRepository) UpsertBuckets(buckets []models.EventStatsBucket) error {
+	if len(buckets) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}, {Name: "period"}, {Name: "bucket_start"}},
+		DoUpdates: clause.AssignmentColumns([]string{"views", "unique_visitors", "qr_scans", "downloads", "guest_uploads", "owner_uploads", "updated_at"}),
+	}).CreateInBatches(&buckets, 500).Error
+}
+
+// LatestBucketStart verilen dönem için en son toplanan zaman diliminin başlangıcını döndürür
+func (r *AnalyticsRepository) LatestBucketStart(period string) (time.Time, error) {
+	var bucket models.EventStatsBucket
+	err := r.db.Where("period = ?", period).Order("bucket_start DESC").First(&bucket).Error
+	if err != nil {
+		return time.Time{}, err
+	}
+	return bucket.BucketStart, nil
+}
+
+// GetBuckets etkinliğin [from, to) aralığındaki istatistiklerini zamana göre sıralı döndürür
+func (r *AnalyticsRepository) GetBuckets(eventID uint, period string, from, to time.Time) ([]models.EventStatsBucket, error) {
+	var buckets []models.EventStatsBucket
+	err := r.db.Where("event_id = ? AND period = ? AND bucket_start >= ? AND bucket_start < ?", eventID, period, from, to).
+		Order("bucket_start ASC").
+		Find(&buckets).Error
+	return buckets, err
+}
+
+// CountUniqueVisitors etkinliği [from, to) aralığında ziyaret eden farklı kişi sayısını döndürür
+func (r *AnalyticsRepository) CountUniqueVisitors(eventID uint, from, to time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.EventHit{}).
+		Where("event_id = ? AND kind = ? AND created_at >= ? AND created_at < ?", eventID, models.HitKindView, from, to).
+		Distinct("visitor_id").
+		Count(&count).Error
+	return count, err
+}
+
+// TopUploaders [from, to) aralığında en çok fotoğraf yükleyenleri döndürür.
+// Misafirler misafir kimliğine, hesapla yapılan yüklemeler kullanıcıya göre gruplanır.
+func (r *AnalyticsRepository) TopUploaders(eventID uint, from, to time.Time, limit int) ([]UploaderCount, error) {
+	var rows []UploaderCount
+	err := r.db.Unscoped().Model(&models.Photos{}).
+		Select("is_guest, MAX(user_id) AS user_id, MAX(guest_name) AS guest_name, COUNT(*) AS count").
+		Where("event_id = ? AND created_at >= ? AND created_at < ?", eventID, from, to).
+		Group("is_guest, CASE WHEN is_guest THEN guest_id ELSE CAST(user_id AS text) END").
+		Order("count DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
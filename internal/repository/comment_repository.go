@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{
+		db: db,
+	}
+}
+
+func (r *CommentRepository) Create(comment *models.PhotoComment) error {
+	return r.db.Create(comment).Error
+}
+
+func (r *CommentRepository) GetByID(id uint) (*models.PhotoComment, error) {
+	var comment models.PhotoComment
+	err := r.db.First(&comment, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *CommentRepository) GetByPhotoID(photoID uint) ([]models.PhotoComment, error) {
+	var comments []models.PhotoComment
+	err := r.db.Where("photo_id = ?", photoID).
+		Order("created_at ASC").
+		Find(&comments).Error
+	return comments, err
+}
+
+// CountByPhotoIDs verilen fotoğrafların yorum sayılarını tek sorguda döndürür
+func (r *CommentRepository) CountByPhotoIDs(photoIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64)
+	if len(photoIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		PhotoID uint
+		Count   int64
+	}
+	err := r.db.Model(&models.PhotoComment{}).
+		Select("photo_id, COUNT(*) AS count").
+		Where("photo_id IN ?", photoIDs).
+		Group("photo_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.PhotoID] = row.Count
+	}
+	return counts, nil
+}
+
+// DeleteWithReplies yorumu ve ona verilen yanıtları siler
+func (r *CommentRepository) DeleteWithReplies(id uint) error {
+	return r.db.Where("id = ? OR parent_id = ?", id, id).Delete(&models.PhotoComment{}).Error
+}
+
+func (r *CommentRepository) DeleteByPhotoID(photoID uint) error {
+	return r.db.Where("photo_id = ?", photoID).Delete(&models.PhotoComment{}).Error
+}
+
+func (r *CommentRepository) DeleteByEventID(eventID uint) error {
+	return r.db.Where("event_id = ?", eventID).Delete(&models.PhotoComment{}).Error
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type EventExtensionRepository struct {
+	db *gorm.DB
+}
+
+func NewEventExtensionRepository(db *gorm.DB) *EventExtensionRepository {
+	return &EventExtensionRepository{
+		db: db,
+	}
+}
+
+func (r *EventExtensionRepository) GetPackageByID(id uint) (*models.EventExtensionPackage, error) {
+	var pkg models.EventExtensionPackage
+	err := r.db.Where("is_active = ?", true).First(&pkg, id).Error
+	return &pkg, err
+}
+
+func (r *EventExtensionRepository) GetActivePackages() ([]models.EventExtensionPackage, error) {
+	var packages []models.EventExtensionPackage
+	err := r.db.Where("is_active = ?", true).Order("price ASC").Find(&packages).Error
+	return packages, err
+}
+
+func (r *EventExtensionRepository) CreatePurchase(purchase *models.EventExtensionPurchase) error {
+	return r.db.Create(purchase).Error
+}
+
+func (r *EventExtensionRepository) GetPurchaseBySessionID(sessionID string) (*models.EventExtensionPurchase, error) {
+	var purchase models.EventExtensionPurchase
+	err := r.db.Where("stripe_session_id = ?", sessionID).First(&purchase).Error
+	return &purchase, err
+}
+
+func (r *EventExtensionRepository) UpdatePurchase(purchase *models.EventExtensionPurchase) error {
+	return r.db.Save(purchase).Error
+}
+
+func (r *EventExtensionRepository) GetPurchasesByUserID(userID uint) ([]models.EventExtensionPurchase, error) {
+	var purchases []models.EventExtensionPurchase
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&purchases).Error
+	return purchases, err
+}
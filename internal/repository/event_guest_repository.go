@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventGuestRepository struct {
+	db *gorm.DB
+}
+
+func NewEventGuestRepository(db *gorm.DB) *EventGuestRepository {
+	return &EventGuestRepository{
+		db: db,
+	}
+}
+
+// CreateMany listede olmayan misafirleri ekler, aynı e-posta zaten varsa atlar. Eklenen kayıt sayısını döndürür.
+func (r *EventGuestRepository) CreateMany(guests []models.EventGuest) (int, error) {
+	if len(guests) == 0 {
+		return 0, nil
+	}
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&guests)
+	return int(result.RowsAffected), result.Error
+}
+
+func (r *EventGuestRepository) GetByID(id uint) (*models.EventGuest, error) {
+	var guest models.EventGuest
+	err := r.db.First(&guest, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &guest, nil
+}
+
+func (r *EventGuestRepository) GetByInviteToken(token string) (*models.EventGuest, error) {
+	var guest models.EventGuest
+	err := r.db.Where("invite_token = ?", token).First(&guest).Error
+	if err != nil {
+		return nil, err
+	}
+	return &guest, nil
+}
+
+func (r *EventGuestRepository) GetByEventID(eventID uint) ([]models.EventGuest, error) {
+	var guests []models.EventGuest
+	err := r.db.Where("event_id = ?", eventID).Order("created_at ASC, id ASC").Find(&guests).Error
+	return guests, err
+}
+
+// GetUninvited davet edilmemiş veya daveti gönderilemeyen misafirleri döndürür. staleBefore'dan
+// önce kuyruğa alınıp gönderimi tamamlanmayan (ör. sunucu yeniden başladığı için) davetler de dahildir.
+func (r *EventGuestRepository) GetUninvited(eventID uint, staleBefore time.Time) ([]models.EventGuest, error) {
+	var guests []models.EventGuest
+	err := r.db.Where("event_id = ? AND (status IN ? OR (status = ? AND updated_at < ?))", eventID,
+		[]string{models.GuestStatusPending, models.GuestStatusFailed}, models.GuestStatusQueued, staleBefore).
+		Find(&guests).Error
+	return guests, err
+}
+
+// MarkQueued henüz davet edilmemiş misafirleri gönderim kuyruğuna alır, gönderilmiş davetlerin durumu değişmez
+func (r *EventGuestRepository) MarkQueued(ids []uint) error {
+	return r.db.Model(&models.EventGuest{}).
+		Where("id IN ? AND status IN ?", ids, []string{models.GuestStatusPending, models.GuestStatusFailed, models.GuestStatusQueued}).
+		Update("status", models.GuestStatusQueued).Error
+}
+
+// SetInvitationResult davet gönderiminin sonucunu kaydeder. Gönderim sırasında açılmış veya
+// ziyaret edilmiş davetin durumu geri alınmaz.
+func (r *EventGuestRepository) SetInvitationResult(guest *models.EventGuest, status string, invitedAt *time.Time) error {
+	updates := map[string]interface{}{
+		"status": gorm.Expr("CASE WHEN status IN ? THEN ? ELSE status END",
+			[]string{models.GuestStatusPending, models.GuestStatusFailed, models.GuestStatusQueued}, status),
+		"access_code_id": guest.AccessCodeID,
+	}
+	if invitedAt != nil {
+		updates["invited_at"] = *invitedAt
+	}
+	return r.db.Model(&models.EventGuest{}).Where("id = ?", guest.ID).Updates(updates).Error
+}
+
+func (r *EventGuestRepository) GetByIDs(eventID uint, ids []uint) ([]models.EventGuest, error) {
+	var guests []models.EventGuest
+	err := r.db.Where("event_id = ? AND id IN ?", eventID, ids).Find(&guests).Error
+	return guests, err
+}
+
+// QueueGalleryNotifications "galeri hazır" duyurusu henüz gönderilmemiş misafirleri tek sorguda
+// gönderim kuyruğuna alır ve döndürür. Kuyruktaki misafirler staleBefore'a kadar tekrar seçilmez,
+// böylece aynı anda yapılan istekler duyuruyu iki kez göndermez.
+func (r *EventGuestRepository) QueueGalleryNotifications(eventID uint, now time.Time, staleBefore time.Time) ([]models.EventGuest, error) {
+	var guests []models.EventGuest
+	err := r.db.Model(&guests).Clauses(clause.Returning{}).
+		Where("event_id = ? AND gallery_notified_at IS NULL AND (gallery_queued_at IS NULL OR gallery_queued_at < ?)", eventID, staleBefore).
+		Update("gallery_queued_at", now).Error
+	return guests, err
+}
+
+func (r *EventGuestRepository) Update(guest *models.EventGuest) error {
+	return r.db.Save(guest).Error
+}
+
+// MarkOpened davetin açıldığını kaydeder. Ziyaret edilmiş davetin durumu geri alınmaz.
+func (r *EventGuestRepository) MarkOpened(id uint, now time.Time) error {
+	return r.db.Model(&models.EventGuest{}).
+		Where("id = ? AND status IN ?", id, []string{models.GuestStatusDelivered, models.GuestStatusOpened}).
+		Updates(map[string]interface{}{
+			"status":    models.GuestStatusOpened,
+			"opened_at": gorm.Expr("COALESCE(opened_at, ?)", now),
+		}).Error
+}
+
+// MarkVisited davetteki bağlantının kullanıldığını kaydeder. Ziyaret e-postanın açıldığını da gösterir.
+func (r *EventGuestRepository) MarkVisited(id uint, now time.Time) error {
+	return r.db.Model(&models.EventGuest{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     models.GuestStatusVisited,
+		"opened_at":  gorm.Expr("COALESCE(opened_at, ?)", now),
+		"visited_at": gorm.Expr("COALESCE(visited_at, ?)", now),
+	}).Error
+}
+
+// SetGalleryResult duyuru gönderiminin sonucunu kaydeder. Gönderilemeyen duyuru kuyruktan çıkar
+// ve tekrar gönderilebilir.
+func (r *EventGuestRepository) SetGalleryResult(id uint, notifiedAt *time.Time) error {
+	return r.db.Model(&models.EventGuest{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"gallery_notified_at": notifiedAt,
+		"gallery_queued_at":   nil,
+	}).Error
+}
+
+func (r *EventGuestRepository) Delete(id uint) error {
+	return r.db.Delete(&models.EventGuest{}, id).Error
+}
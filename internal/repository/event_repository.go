@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"strings"
 	"time"
 
 	"github.com/sefazor/ourphotos-backend/internal/models"
@@ -16,10 +17,26 @@ func NewEventRepository(db *gorm.DB) *EventRepository {
 }
 
 func (r *EventRepository) Create(event *models.Event) (*models.Event, error) {
-	result := r.db.Create(event)
-	if result.Error != nil {
-		return nil, result.Error
+	// GORM varsayılanı true olan alanlarda false değerini varsayılanla değiştirdiği için kapatılan ayarlar ayrıca yazılır
+	isPublic, allowGuestUploads := event.IsPublic, event.AllowGuestUploads
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+		if isPublic && allowGuestUploads {
+			return nil
+		}
+		return tx.Model(event).Updates(map[string]interface{}{
+			"is_public":           isPublic,
+			"allow_guest_uploads": allowGuestUploads,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	event.IsPublic, event.AllowGuestUploads = isPublic, allowGuestUploads
 	return event, nil
 }
 
@@ -46,6 +63,21 @@ func (r *EventRepository) Delete(id uint) error {
 	return r.db.Delete(&models.Event{}, id).Error
 }
 
+// ClearCoverPhoto silinen fotoğrafı kapak olarak kullanan etkinliklerin kapağını kaldırır
+func (r *EventRepository) ClearCoverPhoto(photoID uint) error {
+	return r.db.Unscoped().Model(&models.Event{}).Where("cover_photo_id = ?", photoID).Update("cover_photo_id", nil).Error
+}
+
+// GetByIDUnscoped etkinliği çöp kutusunda olsa bile döndürür
+func (r *EventRepository) GetByIDUnscoped(id uint) (*models.Event, error) {
+	var event models.Event
+	err := r.db.Unscoped().First(&event, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
 func (r *EventRepository) GetByURL(url string) (*models.Event, error) {
 	var event models.Event
 	err := r.db.Where("url = ?", url).First(&event).Error
@@ -55,9 +87,10 @@ func (r *EventRepository) GetByURL(url string) (*models.Event, error) {
 	return &event, nil
 }
 
+// URLExists çöp kutusundaki etkinlikler dahil URL'nin kullanımda olup olmadığını kontrol eder
 func (r *EventRepository) URLExists(url string) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.Event{}).Where("url = ?", url).Count(&count).Error
+	err := r.db.Unscoped().Model(&models.Event{}).Where("url = ?", url).Count(&count).Error
 	return count > 0, err
 }
 
@@ -67,18 +100,227 @@ func (r *EventRepository) GetPhotoCount(eventID uint) (int64, error) {
 	return count, result.Error
 }
 
-func (r *EventRepository) GetByUserID(userID uint) ([]models.Event, error) {
+// eventSortColumns liste sıralama alanlarının sütun karşılıkları
+var eventSortColumns = map[string]string{
+	models.EventSortCreated: "created_at",
+	models.EventSortExpiry:  "expires_at",
+	models.EventSortPhotos:  "photo_count",
+}
+
+// likeEscaper LIKE desenlerinde özel anlamı olan karakterleri kaçırır
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ListByUserID kullanıcının etkinliklerini arama, filtre ve sıralama ayarlarına göre sayfalı olarak döndürür.
+// Sorgunun geçerliliği servis katmanında kontrol edilir.
+func (r *EventRepository) ListByUserID(userID uint, query models.EventListQuery, now time.Time) ([]models.Event, int64, error) {
+	db := r.db.Model(&models.Event{}).Where("user_id = ?", userID)
+
+	if query.Search != "" {
+		pattern := "%" + likeEscaper.Replace(query.Search) + "%"
+		db = db.Where("(title ILIKE ? OR location ILIKE ?)", pattern, pattern)
+	}
+
+	switch query.Status {
+	case models.EventStatusActive:
+		db = db.Where("(is_permanent OR (is_expired = ? AND expires_at > ?))", false, now)
+	case models.EventStatusExpired:
+		db = db.Where("NOT is_permanent AND (is_expired = ? OR expires_at <= ?)", true, now)
+	}
+
+	if query.PasswordProtected {
+		db = db.Where("has_password = ?", true)
+	}
+
+	if query.PendingModeration {
+		db = db.Where(`(EXISTS (SELECT 1 FROM photos WHERE photos.event_id = events.id AND photos.pending_approval AND photos.deleted_at IS NULL)
+			OR EXISTS (SELECT 1 FROM guestbook_entries WHERE guestbook_entries.event_id = events.id AND guestbook_entries.pending_approval))`)
+	}
+
+	var total int64
+	if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	direction := "DESC"
+	if query.Order == "asc" {
+		direction = "ASC"
+	}
+
 	var events []models.Event
-	err := r.db.Where("user_id = ?", userID).Find(&events).Error
+	err := db.Session(&gorm.Session{}).
+		Order(eventSortColumns[query.Sort] + " " + direction).
+		Order("id " + direction).
+		Limit(query.Limit).
+		Offset((query.Page - 1) * query.Limit).
+		Find(&events).Error
+	return events, total, err
+}
+
+// EnsureListIndexes etkinlik listesinin sıralama ve moderasyon filtreleri için indeksleri oluşturur
+func (r *EventRepository) EnsureListIndexes() error {
+	indexes := []string{
+		"CREATE INDEX IF NOT EXISTS idx_events_user_created ON events (user_id, created_at) WHERE deleted_at IS NULL",
+		"CREATE INDEX IF NOT EXISTS idx_events_user_expires ON events (user_id, expires_at) WHERE deleted_at IS NULL",
+		"CREATE INDEX IF NOT EXISTS idx_events_user_photos ON events (user_id, photo_count) WHERE deleted_at IS NULL",
+		"CREATE INDEX IF NOT EXISTS idx_photos_event_pending ON photos (event_id) WHERE pending_approval AND deleted_at IS NULL",
+		"CREATE INDEX IF NOT EXISTS idx_guestbook_entries_pending ON guestbook_entries (event_id) WHERE pending_approval",
+	}
+	for _, index := range indexes {
+		if err := r.db.Exec(index).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *EventRepository) GetDeletedByID(id uint) (*models.Event, error) {
+	var event models.Event
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&event, id).Error
 	if err != nil {
 		return nil, err
 	}
-	return events, nil
+	return &event, nil
+}
+
+// GetDeletedByUserID kullanıcının çöp kutusundaki etkinliklerini en son silinenden başlayarak döndürür
+func (r *EventRepository) GetDeletedByUserID(userID uint) ([]models.Event, error) {
+	var events []models.Event
+	err := r.db.Unscoped().
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC").
+		Find(&events).Error
+	return events, err
+}
+
+// FindDeletedBefore saklama süresi dolan, çöp kutusundaki etkinlikleri döndürür
+func (r *EventRepository) FindDeletedBefore(cutoff time.Time) ([]models.Event, error) {
+	var events []models.Event
+	err := r.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *EventRepository) Restore(id uint) error {
+	return r.db.Unscoped().Model(&models.Event{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+// HardDelete etkinlik kaydını URL geçmişi, anı defteri, davet listesi, erişim kodları ve parola deneme kaydıyla birlikte kalıcı olarak siler
+func (r *EventRepository) HardDelete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ?", id).Delete(&models.EventURLHistory{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("event_id = ?", id).Delete(&models.EventPasswordAttempt{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("event_id = ?", id).Delete(&models.EventAccessCode{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("event_id = ?", id).Delete(&models.GuestbookEntry{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("event_id = ?", id).Delete(&models.EventGuest{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("event_id = ?", id).Delete(&models.EventHit{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("event_id = ?", id).Delete(&models.EventStatsBucket{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("event_id = ?", id).Delete(&models.EventTransfer{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&models.Event{}, id).Error
+	})
+}
+
+// GetURLHistory eski bir etkinlik URL'sinin geçmiş kaydını döndürür
+func (r *EventRepository) GetURLHistory(url string) (*models.EventURLHistory, error) {
+	var history models.EventURLHistory
+	err := r.db.Where("url = ?", url).First(&history).Error
+	if err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// ChangeURL etkinliğin URL'sini değiştirir ve eski URL'yi geçmişe ekler.
+// Etkinlik daha önce kullandığı bir URL'ye dönüyorsa o URL geçmişten çıkarılır.
+func (r *EventRepository) ChangeURL(event *models.Event, newURL string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("event_id = ? AND url = ?", event.ID, newURL).Delete(&models.EventURLHistory{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.EventURLHistory{EventID: event.ID, URL: event.URL}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(event).Update("url", newURL).Error; err != nil {
+			return err
+		}
+		event.URL = newURL
+		return nil
+	})
 }
 
 // FindExpiredEvents belirtilen tarihten önce süresi dolan etkinlikleri bulur
 func (r *EventRepository) FindExpiredEvents(currentTime time.Time) ([]models.Event, error) {
 	var expiredEvents []models.Event
-	err := r.db.Where("expires_at < ?", currentTime).Find(&expiredEvents).Error
+	err := r.db.Where("expires_at < ? AND is_permanent = ?", currentTime, false).Find(&expiredEvents).Error
 	return expiredEvents, err
 }
+
+// MarkExpired süresi dolan etkinlikleri salt okunur olarak işaretler ve etkilenen kayıt sayısını döndürür
+func (r *EventRepository) MarkExpired(currentTime time.Time) (int64, error) {
+	result := r.db.Model(&models.Event{}).
+		Where("expires_at < ? AND is_expired = ? AND is_permanent = ?", currentTime, false, false).
+		Update("is_expired", true)
+	return result.RowsAffected, result.Error
+}
+
+// FindExpiringBetween süresi verilen aralıkta dolacak etkinlikleri bulur
+func (r *EventRepository) FindExpiringBetween(from time.Time, to time.Time) ([]models.Event, error) {
+	var events []models.Event
+	err := r.db.Where("expires_at >= ? AND expires_at < ? AND is_permanent = ?", from, to, false).Find(&events).Error
+	return events, err
+}
+
+func (r *EventRepository) SetExpiryWarningDays(eventID uint, days int) error {
+	return r.db.Model(&models.Event{}).Where("id = ?", eventID).Update("expiry_warning_days", days).Error
+}
+
+// Extend etkinliğin bitiş tarihini günceller, süresi dolmuş etkinliği tekrar aktif hale getirir
+func (r *EventRepository) Extend(eventID uint, expiresAt time.Time) error {
+	return r.db.Unscoped().Model(&models.Event{}).Where("id = ?", eventID).Updates(map[string]interface{}{
+		"expires_at":          expiresAt,
+		"is_expired":          false,
+		"expiry_warning_days": 0,
+	}).Error
+}
+
+// MakePermanent etkinliği kalıcı arşive alır, süresi dolmuş etkinlik tekrar aktif hale gelir
+func (r *EventRepository) MakePermanent(eventID uint) error {
+	return r.db.Unscoped().Model(&models.Event{}).Where("id = ?", eventID).Updates(map[string]interface{}{
+		"is_permanent":        true,
+		"is_expired":          false,
+		"expiry_warning_days": 0,
+	}).Error
+}
+
+// ShortenExpiry iade edilen uzatmadan sonra etkinliğin bitiş tarihini geri alır.
+// Süre uyarıları yeni tarihe göre tekrar gönderilir.
+func (r *EventRepository) ShortenExpiry(eventID uint, expiresAt time.Time) error {
+	return r.db.Unscoped().Model(&models.Event{}).Where("id = ?", eventID).Updates(map[string]interface{}{
+		"expires_at":          expiresAt,
+		"expiry_warning_days": 0,
+	}).Error
+}
+
+// RevokePermanent iade edilen kalıcı arşivi kaldırır, etkinlik kayıtlı bitiş tarihine döner
+func (r *EventRepository) RevokePermanent(eventID uint) error {
+	return r.db.Unscoped().Model(&models.Event{}).Where("id = ?", eventID).Updates(map[string]interface{}{
+		"is_permanent":        false,
+		"expiry_warning_days": 0,
+	}).Error
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type EventTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewEventTemplateRepository(db *gorm.DB) *EventTemplateRepository {
+	return &EventTemplateRepository{
+		db: db,
+	}
+}
+
+func (r *EventTemplateRepository) Create(template *models.EventTemplate) error {
+	return r.db.Create(template).Error
+}
+
+func (r *EventTemplateRepository) GetByID(id uint) (*models.EventTemplate, error) {
+	var template models.EventTemplate
+	err := r.db.First(&template, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *EventTemplateRepository) GetByUserID(userID uint) ([]models.EventTemplate, error) {
+	var templates []models.EventTemplate
+	err := r.db.Where("user_id = ?", userID).Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+
+func (r *EventTemplateRepository) Delete(id uint) error {
+	return r.db.Delete(&models.EventTemplate{}, id).Error
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrEventOwnerChanged devir sırasında etkinliğin sahibi artık devri başlatan kullanıcı değil
+	ErrEventOwnerChanged = errors.New("event owner has changed")
+	// ErrNoEventCredits alıcının etkinlik hakkı kalmamış
+	ErrNoEventCredits = errors.New("no event credits left")
+)
+
+type EventTransferRepository struct {
+	db *gorm.DB
+}
+
+func NewEventTransferRepository(db *gorm.DB) *EventTransferRepository {
+	return &EventTransferRepository{db: db}
+}
+
+func (r *EventTransferRepository) Create(transfer *models.EventTransfer) error {
+	return r.db.Create(transfer).Error
+}
+
+func (r *EventTransferRepository) GetByToken(token string) (*models.EventTransfer, error) {
+	var transfer models.EventTransfer
+	err := r.db.Where("token = ?", token).First(&transfer).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// GetPendingByEventID etkinliğin bekleyen devrini döndürür
+func (r *EventTransferRepository) GetPendingByEventID(eventID uint) (*models.EventTransfer, error) {
+	var transfer models.EventTransfer
+	err := r.db.Where("event_id = ? AND status = ?", eventID, models.TransferStatusPending).
+		Order("created_at DESC").
+		First(&transfer).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// CancelPending etkinliğin bekleyen tüm devirlerini iptal eder
+func (r *EventTransferRepository) CancelPending(eventID uint, now time.Time) error {
+	return r.db.Model(&models.EventTransfer{}).
+		Where("event_id = ? AND status = ?", eventID, models.TransferStatusPending).
+		Updates(map[string]interface{}{"status": models.TransferStatusCancelled, "responded_at": now}).Error
+}
+
+func (r *EventTransferRepository) Update(transfer *models.EventTransfer) error {
+	return r.db.Save(transfer).Error
+}
+
+// Accept devri tek bir transaction içinde tamamlar: etkinlik alıcıya geçer, alıcının etkinlik hakkından
+// bir düşülür ve devreden kullanıcıya bir hak iade edilir. Fotoğrafların yükleyenleri değişmez.
+func (r *EventTransferRepository) Accept(transfer *models.EventTransfer, toUserID uint, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Event{}).
+			Where("id = ? AND user_id = ?", transfer.EventID, transfer.FromUserID).
+			Update("user_id", toUserID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrEventOwnerChanged
+		}
+
+		result = tx.Model(&models.User{}).
+			Where("id = ? AND event_limit > 0", toUserID).
+			Update("event_limit", gorm.Expr("event_limit - 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNoEventCredits
+		}
+
+		if err := tx.Model(&models.User{}).
+			Where("id = ?", transfer.FromUserID).
+			Update("event_limit", gorm.Expr("event_limit + 1")).Error; err != nil {
+			return err
+		}
+
+		transfer.Status = models.TransferStatusAccepted
+		transfer.ToUserID = &toUserID
+		transfer.RespondedAt = &now
+		return tx.Save(transfer).Error
+	})
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type GuestbookRepository struct {
+	db *gorm.DB
+}
+
+func NewGuestbookRepository(db *gorm.DB) *GuestbookRepository {
+	return &GuestbookRepository{
+		db: db,
+	}
+}
+
+func (r *GuestbookRepository) Create(entry *models.GuestbookEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *GuestbookRepository) GetByID(id uint) (*models.GuestbookEntry, error) {
+	var entry models.GuestbookEntry
+	err := r.db.First(&entry, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetPublicByEventID gizlenmemiş ve onaylanmış girdileri en yeniden eskiye sayfalı olarak döndürür
+func (r *GuestbookRepository) GetPublicByEventID(eventID uint, page, limit int) ([]models.GuestbookEntry, int64, error) {
+	query := r.db.Model(&models.GuestbookEntry{}).
+		Where("event_id = ? AND is_hidden = ? AND pending_approval = ?", eventID, false, false)
+	return r.paginate(query, page, limit)
+}
+
+// GetByEventID etkinlik sahibi için gizli ve onay bekleyenler dahil tüm girdileri sayfalı olarak döndürür
+func (r *GuestbookRepository) GetByEventID(eventID uint, page, limit int) ([]models.GuestbookEntry, int64, error) {
+	query := r.db.Model(&models.GuestbookEntry{}).Where("event_id = ?", eventID)
+	return r.paginate(query, page, limit)
+}
+
+func (r *GuestbookRepository) paginate(query *gorm.DB, page, limit int) ([]models.GuestbookEntry, int64, error) {
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []models.GuestbookEntry
+	err := query.Order("created_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&entries).Error
+	return entries, total, err
+}
+
+// GetAllByEventID dışa aktarma için etkinliğin tüm girdilerini eskiden yeniye döndürür
+func (r *GuestbookRepository) GetAllByEventID(eventID uint) ([]models.GuestbookEntry, error) {
+	var entries []models.GuestbookEntry
+	err := r.db.Where("event_id = ?", eventID).Order("created_at ASC").Find(&entries).Error
+	return entries, err
+}
+
+func (r *GuestbookRepository) Update(entry *models.GuestbookEntry) error {
+	return r.db.Save(entry).Error
+}
+
+func (r *GuestbookRepository) Delete(id uint) error {
+	return r.db.Delete(&models.GuestbookEntry{}, id).Error
+}
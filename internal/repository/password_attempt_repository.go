@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EventWideAttemptKey etkinlik genelindeki deneme sayacının IP hash değeri
+const EventWideAttemptKey = ""
+
+// AttemptDelay başarısız deneme sayısına göre bir sonraki denemeye kadar beklenecek süreyi verir
+type AttemptDelay func(failedCount int) time.Duration
+
+// AttemptLockedError parola denemesinin sayaçlardan birinin kilidi açılmadan yapıldığını belirtir
+type AttemptLockedError struct {
+	LockedUntil time.Time
+}
+
+func (e *AttemptLockedError) Error() string {
+	return "password attempts are locked"
+}
+
+type PasswordAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordAttemptRepository(db *gorm.DB) *PasswordAttemptRepository {
+	return &PasswordAttemptRepository{
+		db: db,
+	}
+}
+
+// ClaimAttempt parola denemesini karşılaştırmadan önce istemci ve etkinlik genelindeki sayaçlara
+// tek transaction içinde işler. Kayıtlar satır kilidiyle okunduğundan eşzamanlı denemeler kilit
+// kararını atlayamaz. Sayaçlardan biri kilitliyse hiçbir sayaç değişmez ve AttemptLockedError döner.
+// Son deneme windowStart'tan eskiyse sayaç sıfırdan başlar. Güncel etkinlik geneli kaydını döndürür.
+func (r *PasswordAttemptRepository) ClaimAttempt(eventID uint, ipHash string, now time.Time, windowStart time.Time,
+	clientDelay AttemptDelay, eventDelay AttemptDelay) (*models.EventPasswordAttempt, error) {
+	var eventAttempt models.EventPasswordAttempt
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Kilitlenme sırası tüm denemelerde aynı olsun diye önce etkinlik geneli kaydı oluşturulur
+		for _, key := range []string{EventWideAttemptKey, ipHash} {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.EventPasswordAttempt{
+				EventID:      eventID,
+				IPHash:       key,
+				LastFailedAt: now,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		var attempts []models.EventPasswordAttempt
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ? AND ip_hash IN ?", eventID, []string{EventWideAttemptKey, ipHash}).
+			Order("ip_hash").
+			Find(&attempts).Error; err != nil {
+			return err
+		}
+
+		var lockedUntil time.Time
+		for _, attempt := range attempts {
+			if attempt.LockedUntil != nil && attempt.LockedUntil.After(lockedUntil) {
+				lockedUntil = *attempt.LockedUntil
+			}
+		}
+		if now.Before(lockedUntil) {
+			return &AttemptLockedError{LockedUntil: lockedUntil}
+		}
+
+		for i := range attempts {
+			attempt := &attempts[i]
+			delay := clientDelay
+			if attempt.IPHash == EventWideAttemptKey {
+				delay = eventDelay
+			}
+
+			if attempt.LastFailedAt.Before(windowStart) {
+				attempt.FailedCount = 0
+			}
+			attempt.FailedCount++
+			attempt.LastFailedAt = now
+			attempt.LockedUntil = nil
+			if d := delay(attempt.FailedCount); d > 0 {
+				until := now.Add(d)
+				attempt.LockedUntil = &until
+			}
+
+			if err := tx.Model(attempt).Updates(map[string]interface{}{
+				"failed_count":   attempt.FailedCount,
+				"last_failed_at": attempt.LastFailedAt,
+				"locked_until":   attempt.LockedUntil,
+			}).Error; err != nil {
+				return err
+			}
+
+			if attempt.IPHash == EventWideAttemptKey {
+				eventAttempt = *attempt
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &eventAttempt, nil
+}
+
+// ReleaseAttempt doğru parola ile sonuçlanan denemeyi sayaçlardan geri alır. İstemcinin sayacı
+// ve kilidi sıfırlanır, etkinlik genelindeki sayaçtan ise sadece bu deneme düşülür.
+func (r *PasswordAttemptRepository) ReleaseAttempt(eventID uint, ipHash string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.EventPasswordAttempt{}).
+			Where("event_id = ? AND ip_hash = ?", eventID, EventWideAttemptKey).
+			Update("failed_count", gorm.Expr("GREATEST(failed_count - 1, 0)")).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.EventPasswordAttempt{}).
+			Where("event_id = ? AND ip_hash = ?", eventID, ipHash).
+			Updates(map[string]interface{}{
+				"failed_count": 0,
+				"locked_until": nil,
+			}).Error
+	})
+}
+
+// MarkOwnerNotified sahibine bildirim gönderme hakkını etkinlik genelindeki kayıt üzerinden atomik
+// olarak alır. Pencere içinde başka bir sunucu zaten bildirim gönderdiyse false döner.
+func (r *PasswordAttemptRepository) MarkOwnerNotified(eventID uint, now time.Time, windowStart time.Time) (bool, error) {
+	result := r.db.Model(&models.EventPasswordAttempt{}).
+		Where("event_id = ? AND ip_hash = ? AND (owner_notified_at IS NULL OR owner_notified_at < ?)", eventID, EventWideAttemptKey, windowStart).
+		Update("owner_notified_at", now)
+	return result.RowsAffected > 0, result.Error
+}
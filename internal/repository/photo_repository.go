@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/sefazor/ourphotos-backend/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PhotoRepository struct {
@@ -36,6 +39,120 @@ func (r *PhotoRepository) GetByEventID(eventID uint) ([]models.Photos, error) {
 	return photos, err
 }
 
+// PhotoListOptions etkinlik fotoğrafları listelenirken uygulanacak filtre ve sıralama
+type PhotoListOptions struct {
+	Sort string
+	// PublicOnly true ise gizlenen ve onay bekleyen fotoğraflar dahil edilmez
+	PublicOnly bool
+	// AlbumID 0 değilse sadece o albümdeki fotoğraflar döner
+	AlbumID uint
+}
+
+// ListByEventID etkinlik fotoğraflarını istenen filtre ve sıralamayla döndürür
+func (r *PhotoRepository) ListByEventID(eventID uint, opts PhotoListOptions) ([]models.Photos, error) {
+	var photos []models.Photos
+	query := r.db.Where("photos.event_id = ?", eventID)
+
+	if opts.PublicOnly {
+		query = query.Where("photos.is_hidden = ? AND photos.owner_hidden = ? AND photos.pending_approval = ?", false, false, false)
+	}
+
+	if opts.AlbumID > 0 {
+		query = query.Where("photos.album_id = ?", opts.AlbumID)
+	}
+
+	if opts.Sort == models.PhotoSortMostLiked {
+		// En çok beğenilenden en aza doğru sırala
+		query = query.
+			Joins("LEFT JOIN (SELECT photo_id, COUNT(*) AS like_count FROM photo_reactions WHERE type = ? GROUP BY photo_id) pr ON pr.photo_id = photos.id", models.ReactionHeart).
+			Order("COALESCE(pr.like_count, 0) DESC")
+	}
+
+	err := query.Order("photos.created_at DESC").Find(&photos).Error
+	return photos, err
+}
+
+// SetHidden fotoğrafın moderasyon gizleme durumunu günceller, sahibin gizlemesine dokunmaz
+func (r *PhotoRepository) SetHidden(id uint, hidden bool) error {
+	return r.db.Model(&models.Photos{}).Where("id = ?", id).Update("is_hidden", hidden).Error
+}
+
+func (r *PhotoRepository) GetByIDs(ids []uint) ([]models.Photos, error) {
+	var photos []models.Photos
+	if len(ids) == 0 {
+		return photos, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&photos).Error
+	return photos, err
+}
+
+// UpdateByIDs verilen fotoğrafların alanlarını tek sorguda günceller
+func (r *PhotoRepository) UpdateByIDs(ids []uint, updates map[string]interface{}) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.Photos{}).Where("id IN ?", ids).Updates(updates).Error
+}
+
+// CountByAlbumIDs etkinliğin albümlerindeki fotoğraf sayılarını albüm ID'sine göre döndürür
+func (r *PhotoRepository) CountByAlbumIDs(eventID uint) (map[uint]int64, error) {
+	var rows []struct {
+		AlbumID uint
+		Count   int64
+	}
+	err := r.db.Model(&models.Photos{}).
+		Select("album_id, COUNT(*) AS count").
+		Where("event_id = ? AND album_id IS NOT NULL", eventID).
+		Group("album_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.AlbumID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *PhotoRepository) GetByEventIDAndGuestID(eventID uint, guestID string) ([]models.Photos, error) {
+	var photos []models.Photos
+	err := r.db.Where("event_id = ? AND guest_id = ?", eventID, guestID).
+		Order("created_at DESC").
+		Find(&photos).Error
+	return photos, err
+}
+
+// GetGuestPhotosByEventID misafir yüklemelerini misafir kimliğine göre gruplanabilir sırada döndürür
+func (r *PhotoRepository) GetGuestPhotosByEventID(eventID uint) ([]models.Photos, error) {
+	var photos []models.Photos
+	err := r.db.Where("event_id = ? AND is_guest = ?", eventID, true).
+		Order("guest_id ASC").
+		Order("created_at DESC").
+		Find(&photos).Error
+	return photos, err
+}
+
+// CountGuestUploads misafirin yüklemelerini sayar. Misafir kimliği varsa sadece kimliğe göre sayılır,
+// böylece aynı ağı (NAT) paylaşan misafirler birbirinin sınırını tüketmez. IP sadece kimlik yoksa kullanılır.
+func (r *PhotoRepository) CountGuestUploads(eventID uint, guestID string, ipHash string) (int64, error) {
+	var count int64
+	if guestID == "" && ipHash == "" {
+		return 0, nil
+	}
+
+	query := r.db.Model(&models.Photos{}).Where("event_id = ? AND is_guest = ?", eventID, true)
+	if guestID != "" {
+		query = query.Where("guest_id = ?", guestID)
+	} else {
+		query = query.Where("uploader_ip_hash = ?", ipHash)
+	}
+
+	err := query.Count(&count).Error
+	return count, err
+}
+
 func (r *PhotoRepository) Delete(id uint) error {
 	return r.db.Delete(&models.Photos{}, id).Error
 }
@@ -52,6 +169,120 @@ func (r *PhotoRepository) GetEventPhotoCount(eventID uint) (int64, error) {
 	return count, err
 }
 
-func (r *PhotoRepository) DeleteByEventID(eventID uint) error {
-	return r.db.Where("event_id = ?", eventID).Delete(&models.Photos{}).Error
+// DeleteByIDs fotoğrafları çöp kutusuna taşır
+func (r *PhotoRepository) DeleteByIDs(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Where("id IN ?", ids).Delete(&models.Photos{}).Error
+}
+
+// HardDelete fotoğraf kaydını çöp kutusu dahil kalıcı olarak siler
+func (r *PhotoRepository) HardDelete(id uint) error {
+	return r.db.Unscoped().Delete(&models.Photos{}, id).Error
+}
+
+// HardDeleteByEventID etkinliğin çöp kutusundakiler dahil tüm fotoğraf kayıtlarını kalıcı olarak siler
+func (r *PhotoRepository) HardDeleteByEventID(eventID uint) error {
+	return r.db.Unscoped().Where("event_id = ?", eventID).Delete(&models.Photos{}).Error
+}
+
+// GetAllByEventID etkinliğin çöp kutusundakiler dahil tüm fotoğraflarını döndürür
+func (r *PhotoRepository) GetAllByEventID(eventID uint) ([]models.Photos, error) {
+	var photos []models.Photos
+	err := r.db.Unscoped().Where("event_id = ?", eventID).Find(&photos).Error
+	return photos, err
+}
+
+func (r *PhotoRepository) GetDeletedByID(id uint) (*models.Photos, error) {
+	var photo models.Photos
+	err := r.db.Unscoped().Where("deleted_at IS NOT NULL").First(&photo, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &photo, nil
+}
+
+// GetDeletedByUserID kullanıcının etkinliklerinden çöp kutusuna taşınan fotoğrafları döndürür.
+// Etkinliği de silinmiş fotoğraflar etkinlikle birlikte listelendiği için dahil edilmez.
+func (r *PhotoRepository) GetDeletedByUserID(userID uint, limit, offset int) ([]PhotoEventRow, int64, error) {
+	base := r.db.Unscoped().Model(&models.Photos{}).
+		Joins("JOIN events ON events.id = photos.event_id AND events.deleted_at IS NULL").
+		Where("events.user_id = ? AND photos.deleted_at IS NOT NULL", userID)
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []PhotoEventRow
+	err := base.Session(&gorm.Session{}).
+		Select("photos.*, events.url AS event_url, events.title AS event_title").
+		Order("photos.deleted_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, total, err
+}
+
+// FindDeletedBefore saklama süresi dolan, çöp kutusundaki fotoğrafları döndürür
+func (r *PhotoRepository) FindDeletedBefore(cutoff time.Time, limit int) ([]models.Photos, error) {
+	var photos []models.Photos
+	err := r.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&photos).Error
+	return photos, err
+}
+
+func (r *PhotoRepository) Restore(id uint) error {
+	return r.db.Unscoped().Model(&models.Photos{}).Where("id = ?", id).Update("deleted_at", nil).Error
+}
+
+func (r *PhotoRepository) Update(photo *models.Photos) error {
+	return r.db.Save(photo).Error
+}
+
+// PhotoEventRow fotoğrafı bulunduğu etkinliğin URL ve başlığıyla birlikte taşıyan satır
+type PhotoEventRow struct {
+	models.Photos `gorm:"embedded"`
+	EventURL      string
+	EventTitle    string
+}
+
+// photoSearchDocument fotoğrafın aranabilir alanlarından oluşan tsvector ifadesi
+const photoSearchDocument = "to_tsvector('simple', coalesce(photos.caption, '') || ' ' || coalesce(photos.tags::text, '') || ' ' || coalesce(photos.alt_text, '') || ' ' || coalesce(photos.file_name, ''))"
+
+// EnsureSearchIndex fotoğraf araması için GIN indeksini oluşturur
+func (r *PhotoRepository) EnsureSearchIndex() error {
+	return r.db.Exec("CREATE INDEX IF NOT EXISTS idx_photos_search ON photos USING GIN (" + photoSearchDocument + ")").Error
+}
+
+// SearchUserPhotos kullanıcının etkinliklerindeki fotoğraflarda caption, tag, alt text,
+// dosya adı ve etkinlik başlığı üzerinden full-text arama yapar
+func (r *PhotoRepository) SearchUserPhotos(userID uint, query string, limit, offset int) ([]PhotoEventRow, int64, error) {
+	where := "events.user_id = ? AND (" + photoSearchDocument + " @@ websearch_to_tsquery('simple', ?) OR to_tsvector('simple', events.title) @@ websearch_to_tsquery('simple', ?))"
+
+	base := r.db.Model(&models.Photos{}).
+		Joins("JOIN events ON events.id = photos.event_id AND events.deleted_at IS NULL").
+		Where(where, userID, query, query)
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []PhotoEventRow
+	err := base.Session(&gorm.Session{}).
+		Select("photos.*, events.url AS event_url, events.title AS event_title").
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ts_rank(" + photoSearchDocument + ", websearch_to_tsquery('simple', ?)) DESC, photos.created_at DESC",
+			Vars:               []interface{}{query},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	return rows, total, err
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type PurgeLogRepository struct {
+	db *gorm.DB
+}
+
+func NewPurgeLogRepository(db *gorm.DB) *PurgeLogRepository {
+	return &PurgeLogRepository{
+		db: db,
+	}
+}
+
+func (r *PurgeLogRepository) Create(log *models.EventPurgeLog) error {
+	return r.db.Create(log).Error
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReactionRepository struct {
+	db *gorm.DB
+}
+
+func NewReactionRepository(db *gorm.DB) *ReactionRepository {
+	return &ReactionRepository{
+		db: db,
+	}
+}
+
+// Create reaksiyonu ekler, aynı cihazdan gelen tekrar reaksiyonları sessizce yok sayar
+func (r *ReactionRepository) Create(reaction *models.PhotoReaction) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction).Error
+}
+
+func (r *ReactionRepository) Delete(photoID uint, reactionType string, deviceToken string) error {
+	return r.db.Where("photo_id = ? AND type = ? AND device_token = ?", photoID, reactionType, deviceToken).
+		Delete(&models.PhotoReaction{}).Error
+}
+
+func (r *ReactionRepository) CountByPhotoID(photoID uint, reactionType string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.PhotoReaction{}).
+		Where("photo_id = ? AND type = ?", photoID, reactionType).
+		Count(&count).Error
+	return count, err
+}
+
+// CountByPhotoIDs verilen fotoğrafların reaksiyon sayılarını tek sorguda döndürür
+func (r *ReactionRepository) CountByPhotoIDs(photoIDs []uint, reactionType string) (map[uint]int64, error) {
+	counts := make(map[uint]int64)
+	if len(photoIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		PhotoID uint
+		Count   int64
+	}
+	err := r.db.Model(&models.PhotoReaction{}).
+		Select("photo_id, COUNT(*) AS count").
+		Where("photo_id IN ? AND type = ?", photoIDs, reactionType).
+		Group("photo_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.PhotoID] = row.Count
+	}
+	return counts, nil
+}
+
+// PhotoIDsReactedBy cihazın reaksiyon verdiği fotoğrafların ID'lerini döndürür
+func (r *ReactionRepository) PhotoIDsReactedBy(deviceToken string, photoIDs []uint, reactionType string) (map[uint]bool, error) {
+	reacted := make(map[uint]bool)
+	if deviceToken == "" || len(photoIDs) == 0 {
+		return reacted, nil
+	}
+
+	var ids []uint
+	err := r.db.Model(&models.PhotoReaction{}).
+		Where("device_token = ? AND photo_id IN ? AND type = ?", deviceToken, photoIDs, reactionType).
+		Pluck("photo_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		reacted[id] = true
+	}
+	return reacted, nil
+}
+
+func (r *ReactionRepository) DeleteByPhotoID(photoID uint) error {
+	return r.db.Where("photo_id = ?", photoID).Delete(&models.PhotoReaction{}).Error
+}
+
+func (r *ReactionRepository) DeleteByEventID(eventID uint) error {
+	return r.db.Where("event_id = ?", eventID).Delete(&models.PhotoReaction{}).Error
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) *ReportRepository {
+	return &ReportRepository{
+		db: db,
+	}
+}
+
+// Create şikayeti kaydeder. Aynı ziyaretçi fotoğrafı daha önce şikayet ettiyse false döner.
+func (r *ReportRepository) Create(report *models.PhotoReport) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ReportRepository) GetByID(id uint) (*models.PhotoReport, error) {
+	var report models.PhotoReport
+	err := r.db.First(&report, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *ReportRepository) CountOpenByPhotoID(photoID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.PhotoReport{}).
+		Where("photo_id = ? AND status = ?", photoID, models.ReportStatusOpen).
+		Count(&count).Error
+	return count, err
+}
+
+// CountOpenReporterIPsByPhotoID fotoğrafı şikayet eden farklı IP adreslerinin sayısını döndürür
+func (r *ReportRepository) CountOpenReporterIPsByPhotoID(photoID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.PhotoReport{}).
+		Where("photo_id = ? AND status = ?", photoID, models.ReportStatusOpen).
+		Distinct("reporter_ip").
+		Count(&count).Error
+	return count, err
+}
+
+// List şikayetleri duruma göre filtreleyip en yeniden eskiye sayfalı olarak döndürür
+func (r *ReportRepository) List(status string, limit, offset int) ([]models.PhotoReport, int64, error) {
+	query := r.db.Model(&models.PhotoReport{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reports []models.PhotoReport
+	err := query.Session(&gorm.Session{}).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&reports).Error
+	return reports, total, err
+}
+
+// CloseOpenByPhotoID fotoğrafa ait tüm açık şikayetleri verilen durumla kapatır
+func (r *ReportRepository) CloseOpenByPhotoID(photoID uint, status string, resolvedBy uint) error {
+	now := time.Now()
+	return r.db.Model(&models.PhotoReport{}).
+		Where("photo_id = ? AND status = ?", photoID, models.ReportStatusOpen).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"resolved_by": resolvedBy,
+			"resolved_at": now,
+		}).Error
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/repository"
+)
+
+type AlbumService struct {
+	albumRepo *repository.AlbumRepository
+	photoRepo *repository.PhotoRepository
+	eventRepo *repository.EventRepository
+}
+
+func NewAlbumService(
+	albumRepo *repository.AlbumRepository,
+	photoRepo *repository.PhotoRepository,
+	eventRepo *repository.EventRepository,
+) *AlbumService {
+	return &AlbumService{
+		albumRepo: albumRepo,
+		photoRepo: photoRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+// ListAlbums etkinliğin albümlerini fotoğraf sayılarıyla birlikte döndürür
+func (s *AlbumService) ListAlbums(event *models.Event) ([]models.AlbumResponse, error) {
+	albums, err := s.albumRepo.GetByEventID(event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.photoRepo.CountByAlbumIDs(event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.AlbumResponse, 0, len(albums))
+	for _, album := range albums {
+		responses = append(responses, toAlbumResponse(album, counts[album.ID]))
+	}
+
+	return responses, nil
+}
+
+func (s *AlbumService) CreateAlbum(eventID uint, userID uint, req models.CreateAlbumRequest) (*models.AlbumResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	// Yetki kontrolü
+	if event.UserID != userID {
+		return nil, errors.New("unauthorized")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("album name is required")
+	}
+
+	album := &models.PhotoAlbum{
+		EventID: event.ID,
+		Name:    name,
+	}
+	if err := s.albumRepo.Create(album); err != nil {
+		return nil, err
+	}
+
+	response := toAlbumResponse(*album, 0)
+	return &response, nil
+}
+
+// DeleteAlbum albümü siler, içindeki fotoğraflar etkinlikte albümsüz olarak kalır
+func (s *AlbumService) DeleteAlbum(eventID uint, albumID uint, userID uint) error {
+	album, err := s.albumRepo.GetByID(albumID)
+	if err != nil || album.EventID != eventID {
+		return errors.New("album not found")
+	}
+
+	event, err := s.eventRepo.GetByID(album.EventID)
+	if err != nil {
+		return errors.New("event not found")
+	}
+
+	// Yetki kontrolü
+	if event.UserID != userID {
+		return errors.New("unauthorized")
+	}
+
+	return s.albumRepo.Delete(album.ID)
+}
+
+func toAlbumResponse(album models.PhotoAlbum, photoCount int64) models.AlbumResponse {
+	return models.AlbumResponse{
+		ID:         album.ID,
+		EventID:    album.EventID,
+		Name:       album.Name,
+		PhotoCount: photoCount,
+		CreatedAt:  album.CreatedAt,
+	}
+}
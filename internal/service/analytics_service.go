@@ -0,0 +1,321 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/repository"
+)
+
+// AnalyticsAggregationInterval ham ziyaret kayıtlarının istatistiklere toplanma sıklığı
+const AnalyticsAggregationInterval = 15 * time.Minute
+
+const (
+	maxAnalyticsBuckets = 1000
+	topUploaderLimit    = 10
+)
+
+type AnalyticsService struct {
+	analyticsRepo *repository.AnalyticsRepository
+	eventRepo     *repository.EventRepository
+	photoRepo     *repository.PhotoRepository
+	userRepo      *repository.UserRepository
+
+	mu           sync.Mutex
+	lastRunStart time.Time // Son toplama işinin başladığı an
+}
+
+func NewAnalyticsService(
+	analyticsRepo *repository.AnalyticsRepository,
+	eventRepo *repository.EventRepository,
+	photoRepo *repository.PhotoRepository,
+	userRepo *repository.UserRepository,
+) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		eventRepo:     eventRepo,
+		photoRepo:     photoRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// visitorID ziyaretçiyi misafir kimliğiyle, kimlik yoksa hashlenmiş IP adresiyle tanımlar
+func visitorID(guestID, ip string) string {
+	if guestID != "" {
+		return guestID
+	}
+	return hashDeviceToken(ip)
+}
+
+// RecordView galeri ziyaretini kaydeder. QR kod taramasıyla gelindiyse source "qr" olur.
+func (s *AnalyticsService) RecordView(eventID uint, source, guestID, ip string) error {
+	if source != models.HitSourceQR {
+		source = ""
+	}
+
+	return s.analyticsRepo.CreateHit(&models.EventHit{
+		EventID:   eventID,
+		Kind:      models.HitKindView,
+		Source:    source,
+		VisitorID: visitorID(guestID, ip),
+	})
+}
+
+// RecordDownload etkinliğe ait bir fotoğrafın indirilmesini kaydeder
+func (s *AnalyticsService) RecordDownload(eventID uint, photoID uint, guestID, ip string) error {
+	photo, err := s.photoRepo.GetByID(photoID)
+	if err != nil || photo.EventID != eventID || photo.Hidden() || photo.PendingApproval {
+		return errors.New("photo not found")
+	}
+
+	return s.analyticsRepo.CreateHit(&models.EventHit{
+		EventID:   eventID,
+		Kind:      models.HitKindDownload,
+		VisitorID: visitorID(guestID, ip),
+		PhotoID:   &photo.ID,
+	})
+}
+
+// AggregateHits ham ziyaret kayıtlarını ve fotoğraf yüklemelerini saatlik ve günlük istatistiklere toplar.
+// Her çalışmada son toplamanın yapıldığı günün başından itibaren tüm dilimler yeniden hesaplanır,
+// böylece toplama sırasında gelen kayıtlar bir sonraki çalışmada kaçırılmaz.
+func (s *AnalyticsService) AggregateHits() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := time.Now().UTC()
+
+	since := s.lastRunStart
+	if since.IsZero() {
+		// Uygulama yeniden başladıysa kaldığı son günden devam et, hiç toplama yoksa tüm kayıtları topla
+		if latest, err := s.analyticsRepo.LatestBucketStart(models.AnalyticsIntervalDay); err == nil {
+			since = latest
+		}
+	}
+	since = truncateToPeriod(since, models.AnalyticsIntervalDay)
+
+	for _, period := range []string{models.AnalyticsIntervalHour, models.AnalyticsIntervalDay} {
+		hits, err := s.analyticsRepo.AggregateHits(period, since)
+		if err != nil {
+			return fmt.Errorf("failed to aggregate hits: %w", err)
+		}
+
+		uploads, err := s.analyticsRepo.AggregateUploads(period, since)
+		if err != nil {
+			return fmt.Errorf("failed to aggregate uploads: %w", err)
+		}
+
+		buckets := mergeStatsBuckets(period, start, hits, uploads)
+		if err := s.analyticsRepo.UpsertBuckets(buckets); err != nil {
+			return fmt.Errorf("failed to save analytics: %w", err)
+		}
+	}
+
+	s.lastRunStart = start
+	return nil
+}
+
+// mergeStatsBuckets ziyaret ve yükleme toplamlarını aynı etkinlik ve zaman dilimi için birleştirir
+func mergeStatsBuckets(period string, now time.Time, hits, uploads []models.EventStatsBucket) []models.EventStatsBucket {
+	type bucketKey struct {
+		eventID uint
+		start   int64
+	}
+
+	merged := make(map[bucketKey]*models.EventStatsBucket)
+	var order []bucketKey
+
+	get := func(b models.EventStatsBucket) *models.EventStatsBucket {
+		key := bucketKey{eventID: b.EventID, start: b.BucketStart.Unix()}
+		if existing, ok := merged[key]; ok {
+			return existing
+		}
+		bucket := &models.EventStatsBucket{
+			EventID:     b.EventID,
+			Period:      period,
+			BucketStart: b.BucketStart.UTC(),
+			UpdatedAt:   now,
+		}
+		merged[key] = bucket
+		order = append(order, key)
+		return bucket
+	}
+
+	for _, hit := range hits {
+		bucket := get(hit)
+		bucket.Views = hit.Views
+		bucket.UniqueVisitors = hit.UniqueVisitors
+		bucket.QRScans = hit.QRScans
+		bucket.Downloads = hit.Downloads
+	}
+	for _, upload := range uploads {
+		bucket := get(upload)
+		bucket.GuestUploads = upload.GuestUploads
+		bucket.OwnerUploads = upload.OwnerUploads
+	}
+
+	buckets := make([]models.EventStatsBucket, 0, len(order))
+	for _, key := range order {
+		buckets = append(buckets, *merged[key])
+	}
+	return buckets
+}
+
+// truncateToPeriod zamanı UTC'ye göre saatin veya günün başına yuvarlar
+func truncateToPeriod(t time.Time, period string) time.Time {
+	t = t.UTC()
+	if period == models.AnalyticsIntervalDay {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return t.Truncate(time.Hour)
+}
+
+// periodStep zaman diliminin uzunluğunu döndürür
+func periodStep(period string) time.Duration {
+	if period == models.AnalyticsIntervalDay {
+		return 24 * time.Hour
+	}
+	return time.Hour
+}
+
+// GetEventAnalytics etkinlik sahibine seçilen tarih aralığı için zaman dilimli istatistikleri döndürür.
+// from ve to boşsa etkinliğin oluşturulmasından bugüne kadarki veriler, interval boşsa aralığa uygun dilim kullanılır.
+func (s *AnalyticsService) GetEventAnalytics(eventID uint, userID uint, from, to *time.Time, interval string) (*models.EventAnalyticsResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+	if event.UserID != userID {
+		return nil, errors.New("unauthorized")
+	}
+
+	rangeStart, rangeEnd := event.CreatedAt, time.Now()
+	if from != nil {
+		rangeStart = *from
+	}
+	if to != nil {
+		rangeEnd = *to
+	}
+	if !rangeEnd.After(rangeStart) {
+		return nil, errors.New("invalid date range")
+	}
+
+	switch interval {
+	case "":
+		interval = models.AnalyticsIntervalHour
+		if rangeEnd.Sub(rangeStart) > 72*time.Hour {
+			interval = models.AnalyticsIntervalDay
+		}
+	case models.AnalyticsIntervalHour, models.AnalyticsIntervalDay:
+	default:
+		return nil, errors.New("invalid interval")
+	}
+
+	step := periodStep(interval)
+	rangeStart = truncateToPeriod(rangeStart, interval)
+	rangeEnd = rangeEnd.UTC()
+	if rangeEnd.Sub(rangeStart)/step >= maxAnalyticsBuckets {
+		return nil, errors.New("date range is too large for the selected interval")
+	}
+
+	stored, err := s.analyticsRepo.GetBuckets(event.ID, interval, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	byStart := make(map[int64]models.EventStatsBucket, len(stored))
+	for _, bucket := range stored {
+		byStart[bucket.BucketStart.Unix()] = bucket
+	}
+
+	// Veri olmayan dilimler grafiklerde boşluk oluşmaması için sıfır değerle doldurulur
+	response := &models.EventAnalyticsResponse{
+		From:     rangeStart,
+		To:       rangeEnd,
+		Interval: interval,
+		Series:   []models.EventStatsBucket{},
+	}
+	for t := rangeStart; t.Before(rangeEnd); t = t.Add(step) {
+		bucket, ok := byStart[t.Unix()]
+		if !ok {
+			bucket = models.EventStatsBucket{BucketStart: t}
+		}
+		bucket.BucketStart = bucket.BucketStart.UTC()
+		response.Series = append(response.Series, bucket)
+
+		response.Totals.Views += bucket.Views
+		response.Totals.QRScans += bucket.QRScans
+		response.Totals.Downloads += bucket.Downloads
+		response.Totals.GuestUploads += bucket.GuestUploads
+		response.Totals.OwnerUploads += bucket.OwnerUploads
+	}
+	response.Totals.Uploads = response.Totals.GuestUploads + response.Totals.OwnerUploads
+
+	// Tekil ziyaretçiler dilimler toplanarak bulunamaz, ham kayıtlardan sayılır
+	if response.Totals.UniqueVisitors, err = s.analyticsRepo.CountUniqueVisitors(event.ID, rangeStart, rangeEnd); err != nil {
+		return nil, err
+	}
+
+	uploaders, err := s.analyticsRepo.TopUploaders(event.ID, rangeStart, rangeEnd, topUploaderLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	response.TopUploaders = make([]models.TopUploader, 0, len(uploaders))
+	for _, uploader := range uploaders {
+		// Hesapla yüklenen fotoğraflar yükleyen kullanıcının adıyla listelenir
+		name := uploader.GuestName
+		if !uploader.IsGuest {
+			if user, err := s.userRepo.GetByID(uploader.UserID); err == nil {
+				name = user.FullName
+			}
+		}
+		if name == "" {
+			name = "Anonymous guest"
+		}
+
+		response.TopUploaders = append(response.TopUploaders, models.TopUploader{
+			Name:       name,
+			IsGuest:    uploader.IsGuest,
+			PhotoCount: uploader.Count,
+		})
+	}
+
+	return response, nil
+}
+
+// ExportEventAnalytics zaman dilimli istatistikleri CSV olarak döndürür
+func (s *AnalyticsService) ExportEventAnalytics(eventID uint, userID uint, from, to *time.Time, interval string) ([]byte, error) {
+	analytics, err := s.GetEventAnalytics(eventID, userID, from, to, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"bucket_start", "views", "unique_visitors", "qr_scans", "downloads", "guest_uploads", "owner_uploads"})
+
+	for _, bucket := range analytics.Series {
+		writer.Write([]string{
+			bucket.BucketStart.Format(time.RFC3339),
+			strconv.FormatInt(bucket.Views, 10),
+			strconv.FormatInt(bucket.UniqueVisitors, 10),
+			strconv.FormatInt(bucket.QRScans, 10),
+			strconv.FormatInt(bucket.Downloads, 10),
+			strconv.FormatInt(bucket.GuestUploads, 10),
+			strconv.FormatInt(bucket.OwnerUploads, 10),
+		})
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+)
+
+func TestMergeStatsBuckets(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 30, 0, 0, time.UTC)
+	h10 := time.Date(2024, 6, 15, 10, 0, 0, 0, time.UTC)
+	h11 := h10.Add(time.Hour)
+	// Aynı an farklı saat diliminde gelse de aynı dilime düşmeli
+	h11Local := h11.In(time.FixedZone("TRT", 3*60*60))
+
+	hits := []models.EventStatsBucket{
+		{EventID: 1, BucketStart: h10, Views: 5, UniqueVisitors: 3, QRScans: 2, Downloads: 1},
+		{EventID: 2, BucketStart: h10, Views: 7, UniqueVisitors: 7},
+	}
+	uploads := []models.EventStatsBucket{
+		{EventID: 1, BucketStart: h10, GuestUploads: 4, OwnerUploads: 1},
+		{EventID: 1, BucketStart: h11Local, GuestUploads: 2},
+	}
+
+	want := []models.EventStatsBucket{
+		{EventID: 1, Period: models.AnalyticsIntervalHour, BucketStart: h10, Views: 5, UniqueVisitors: 3, QRScans: 2, Downloads: 1, GuestUploads: 4, OwnerUploads: 1, UpdatedAt: now},
+		{EventID: 2, Period: models.AnalyticsIntervalHour, BucketStart: h10, Views: 7, UniqueVisitors: 7, UpdatedAt: now},
+		{EventID: 1, Period: models.AnalyticsIntervalHour, BucketStart: h11, GuestUploads: 2, UpdatedAt: now},
+	}
+
+	got := mergeStatsBuckets(models.AnalyticsIntervalHour, now, hits, uploads)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("mergeStatsBuckets() =\n%+v\nwant\n%+v", got, want)
+	}
+	if got[2].BucketStart.Location() != time.UTC {
+		t.Errorf("bucket start is not normalized to UTC: %v", got[2].BucketStart)
+	}
+}
+
+func TestMergeStatsBucketsEmpty(t *testing.T) {
+	got := mergeStatsBuckets(models.AnalyticsIntervalDay, time.Now(), nil, nil)
+	if len(got) != 0 {
+		t.Errorf("mergeStatsBuckets() returned %d buckets, want 0", len(got))
+	}
+}
+
+func TestTruncateToPeriod(t *testing.T) {
+	ts := time.Date(2024, 6, 15, 23, 45, 10, 0, time.FixedZone("TRT", 3*60*60))
+
+	if got, want := truncateToPeriod(ts, models.AnalyticsIntervalHour), time.Date(2024, 6, 15, 20, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("hour truncation = %v, want %v", got, want)
+	}
+	if got, want := truncateToPeriod(ts, models.AnalyticsIntervalDay), time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("day truncation = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/repository"
+)
+
+type CommentService struct {
+	commentRepo *repository.CommentRepository
+	photoRepo   *repository.PhotoRepository
+	eventRepo   *repository.EventRepository
+	userRepo    *repository.UserRepository
+}
+
+func NewCommentService(
+	commentRepo *repository.CommentRepository,
+	photoRepo *repository.PhotoRepository,
+	eventRepo *repository.EventRepository,
+	userRepo *repository.UserRepository,
+) *CommentService {
+	return &CommentService{
+		commentRepo: commentRepo,
+		photoRepo:   photoRepo,
+		eventRepo:   eventRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// ListComments fotoğrafın yorumlarını yanıtlarıyla birlikte ağaç yapısında döndürür
+func (s *CommentService) ListComments(eventID uint, photoID uint) ([]models.CommentResponse, error) {
+	photo, err := s.photoRepo.GetByID(photoID)
+	if err != nil || photo.EventID != eventID {
+		return nil, errors.New("photo not found")
+	}
+
+	comments, err := s.commentRepo.GetByPhotoID(photo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Yorum yazan kullanıcıların isimlerini topla
+	authorNames := make(map[uint]string)
+	for _, comment := range comments {
+		if comment.UserID == nil {
+			continue
+		}
+		if _, ok := authorNames[*comment.UserID]; ok {
+			continue
+		}
+		if user, err := s.userRepo.GetByID(*comment.UserID); err == nil {
+			authorNames[user.ID] = user.FullName
+		}
+	}
+
+	// Önce ana yorumları, sonra yanıtları yerleştir
+	roots := make([]models.CommentResponse, 0)
+	rootIndex := make(map[uint]int)
+	for _, comment := range comments {
+		if comment.ParentID != nil {
+			continue
+		}
+		rootIndex[comment.ID] = len(roots)
+		roots = append(roots, toCommentResponse(comment, authorNames))
+	}
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			continue
+		}
+		if i, ok := rootIndex[*comment.ParentID]; ok {
+			roots[i].Replies = append(roots[i].Replies, toCommentResponse(comment, authorNames))
+		}
+	}
+
+	return roots, nil
+}
+
+// CreateComment fotoğrafa yorum ekler. userID 0 ise yorum misafir adına kaydedilir.
+func (s *CommentService) CreateComment(event *models.Event, photoID uint, userID uint, req models.CreateCommentRequest) (*models.CommentResponse, error) {
+	if event.CommentsDisabled {
+		return nil, errors.New("comments are disabled for this event")
+	}
+
+	photo, err := s.photoRepo.GetByID(photoID)
+	if err != nil || photo.EventID != event.ID {
+		return nil, errors.New("photo not found")
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, errors.New("comment body is required")
+	}
+
+	comment := &models.PhotoComment{
+		PhotoID: photo.ID,
+		EventID: event.ID,
+		Body:    body,
+	}
+
+	authorNames := make(map[uint]string)
+	if userID > 0 {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		comment.UserID = &user.ID
+		authorNames[user.ID] = user.FullName
+	} else {
+		comment.GuestName = strings.TrimSpace(req.GuestName)
+		if comment.GuestName == "" {
+			return nil, errors.New("guest name is required")
+		}
+	}
+
+	// Yanıtlar tek seviyeli tutulur, bir yanıta verilen yanıt ana yoruma bağlanır
+	if req.ParentID != nil {
+		parent, err := s.commentRepo.GetByID(*req.ParentID)
+		if err != nil || parent.PhotoID != photo.ID {
+			return nil, errors.New("parent comment not found")
+		}
+		if parent.ParentID != nil {
+			comment.ParentID = parent.ParentID
+		} else {
+			comment.ParentID = &parent.ID
+		}
+	}
+
+	if err := s.commentRepo.Create(comment); err != nil {
+		return nil, err
+	}
+
+	response := toCommentResponse(*comment, authorNames)
+	return &response, nil
+}
+
+// DeleteComment etkinlik sahibinin bir yorumu yanıtlarıyla birlikte silmesini sağlar
+func (s *CommentService) DeleteComment(commentID uint, userID uint) error {
+	comment, err := s.commentRepo.GetByID(commentID)
+	if err != nil {
+		return errors.New("comment not found")
+	}
+
+	event, err := s.eventRepo.GetByID(comment.EventID)
+	if err != nil {
+		return errors.New("event not found")
+	}
+
+	// Yetki kontrolü
+	if event.UserID != userID {
+		return errors.New("unauthorized")
+	}
+
+	return s.commentRepo.DeleteWithReplies(comment.ID)
+}
+
+func toCommentResponse(comment models.PhotoComment, authorNames map[uint]string) models.CommentResponse {
+	response := models.CommentResponse{
+		ID:        comment.ID,
+		PhotoID:   comment.PhotoID,
+		ParentID:  comment.ParentID,
+		UserID:    comment.UserID,
+		IsGuest:   comment.UserID == nil,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+	}
+
+	if comment.UserID != nil {
+		response.AuthorName = authorNames[*comment.UserID]
+	} else {
+		response.AuthorName = comment.GuestName
+	}
+
+	return response
+}
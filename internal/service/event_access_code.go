@@ -0,0 +1,252 @@
+package service
+
+import (
+	"crypto/hmac"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/repository"
+	"github.com/sefazor/ourphotos-backend/pkg/bcrypt"
+	jwtPkg "github.com/sefazor/ourphotos-backend/pkg/jwt"
+)
+
+// Erişim kodları karışabilecek karakterler (0/O, 1/I) olmadan okunabilir şekilde üretilir
+const (
+	accessCodeCharset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	accessCodeLength  = 8
+)
+
+// Bir etkinlikte sahibinin oluşturduğu ve misafirlere verilen toplam en fazla erişim kodu
+const maxAccessCodesPerEvent = 2000
+
+// generateAccessCode rastgele bir erişim kodu üretir
+func generateAccessCode() (string, error) {
+	randomBytes := make([]byte, accessCodeLength)
+	if _, err := cryptorand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	return encodeAccessCode(randomBytes), nil
+}
+
+// encodeAccessCode baytları erişim kodu karakter setine dönüştürür
+func encodeAccessCode(b []byte) string {
+	code := make([]byte, accessCodeLength)
+	for i := range code {
+		code[i] = accessCodeCharset[int(b[i])%len(accessCodeCharset)]
+	}
+	return string(code)
+}
+
+// accessCodeLookup kodun anahtarlı hash'ini döndürür. Girilen kod bu değerle doğrudan bulunur,
+// böylece parola denemesinde tüm kodlar yerine en fazla bir kod bcrypt ile karşılaştırılır.
+func accessCodeLookup(code string) string {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("JWT_SECRET")))
+	mac.Write([]byte("access-code-lookup:" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// checkAccessCodeLimit etkinliğin erişim kodu sınırına ulaşılıp ulaşılmadığını kontrol eder
+func checkAccessCodeLimit(repo *repository.AccessCodeRepository, eventID uint) error {
+	count, err := repo.CountByEventID(eventID)
+	if err != nil {
+		return err
+	}
+	if count >= maxAccessCodesPerEvent {
+		return errors.New("access code limit reached for this event")
+	}
+	return nil
+}
+
+func isAccessCodeExpired(code *models.EventAccessCode, now time.Time) bool {
+	return code.ExpiresAt != nil && !code.ExpiresAt.After(now)
+}
+
+// matchAccessCode parolayı etkinliğin aktif erişim kodlarıyla karşılaştırır, eşleşen kodu döndürür.
+// Aday kod anahtarlı hash ile bulunur ve sadece o kod bcrypt ile doğrulanır.
+func (s *EventService) matchAccessCode(eventID uint, password string, now time.Time) *models.EventAccessCode {
+	code, err := s.accessCodeRepo.GetActiveByLookup(eventID, accessCodeLookup(password), now)
+	if err != nil {
+		return nil
+	}
+
+	if bcrypt.ComparePassword(code.CodeHash, password) != nil {
+		return nil
+	}
+	return code
+}
+
+// VerifyEventAccess parola korumalı etkinlik için erişim tokenini doğrular ve tokenin verdiği rolü döndürür.
+// Erişim koduyla alınan tokenler kod silinince veya süresi dolunca geçersiz olur.
+func (s *EventService) VerifyEventAccess(event *models.Event, token string) (string, error) {
+	if !event.HasPassword {
+		return models.AccessRoleUpload, nil
+	}
+
+	codeID, err := jwtPkg.ValidateEventAccessToken(token, event.ID, event.PasswordVersion)
+	if err != nil {
+		return "", err
+	}
+
+	// Etkinlik parolasıyla alınan token
+	if codeID == 0 {
+		return models.AccessRoleUpload, nil
+	}
+
+	code, err := s.accessCodeRepo.GetByID(codeID)
+	if err != nil || code.EventID != event.ID {
+		return "", errors.New("access code has been revoked")
+	}
+	if isAccessCodeExpired(code, time.Now()) {
+		return "", errors.New("access code has expired")
+	}
+
+	return code.Role, nil
+}
+
+// getOwnedEvent etkinliği getirir ve kullanıcının sahibi olduğunu doğrular
+func (s *EventService) getOwnedEvent(eventID uint, userID uint) (*models.Event, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+	if event.UserID != userID {
+		return nil, errors.New("unauthorized")
+	}
+	return event, nil
+}
+
+func (s *EventService) getEventAccessCode(eventID uint, codeID uint) (*models.EventAccessCode, error) {
+	code, err := s.accessCodeRepo.GetByID(codeID)
+	if err != nil || code.EventID != eventID {
+		return nil, errors.New("access code not found")
+	}
+	return code, nil
+}
+
+func (s *EventService) GetAccessCodes(eventID uint, userID uint) ([]models.EventAccessCode, error) {
+	if _, err := s.getOwnedEvent(eventID, userID); err != nil {
+		return nil, err
+	}
+	return s.accessCodeRepo.GetByEventID(eventID)
+}
+
+// CreateAccessCode etkinlik için yeni bir erişim kodu oluşturur.
+// Kod sadece hashlenmiş olarak saklandığından açık hali yalnızca bu yanıtta döner.
+func (s *EventService) CreateAccessCode(eventID uint, userID uint, req models.CreateAccessCodeRequest) (*models.AccessCodeResponse, error) {
+	event, err := s.getOwnedEvent(eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !event.HasPassword {
+		return nil, errors.New("event is not password protected")
+	}
+
+	label := strings.TrimSpace(req.Label)
+	if label == "" {
+		return nil, errors.New("label is required")
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("expiry must be in the future")
+	}
+
+	if err := checkAccessCodeLimit(s.accessCodeRepo, event.ID); err != nil {
+		return nil, err
+	}
+
+	plainCode := strings.TrimSpace(req.Code)
+	if plainCode == "" {
+		plainCode, err = generateAccessCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate access code: %w", err)
+		}
+	}
+
+	// Etkinlik parolası veya başka bir aktif kodla aynı olursa hangi rolün verileceği belirsiz kalır
+	if bcrypt.ComparePassword(event.Password, plainCode) == nil || s.matchAccessCode(event.ID, plainCode, time.Now()) != nil {
+		return nil, errors.New("access code is already in use")
+	}
+
+	codeHash, err := bcrypt.HashPassword(plainCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash access code: %w", err)
+	}
+
+	code := &models.EventAccessCode{
+		EventID:    event.ID,
+		Label:      label,
+		CodeHash:   codeHash,
+		CodeLookup: accessCodeLookup(plainCode),
+		Role:       req.Role,
+		ExpiresAt:  req.ExpiresAt,
+	}
+
+	if err := s.accessCodeRepo.Create(code); err != nil {
+		return nil, err
+	}
+
+	return &models.AccessCodeResponse{
+		EventAccessCode: *code,
+		Code:            plainCode,
+	}, nil
+}
+
+func (s *EventService) UpdateAccessCode(eventID uint, userID uint, codeID uint, req models.UpdateAccessCodeRequest) (*models.EventAccessCode, error) {
+	if _, err := s.getOwnedEvent(eventID, userID); err != nil {
+		return nil, err
+	}
+
+	code, err := s.getEventAccessCode(eventID, codeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Label != nil {
+		label := strings.TrimSpace(*req.Label)
+		if label == "" {
+			return nil, errors.New("label is required")
+		}
+		code.Label = label
+	}
+
+	if req.Role != nil {
+		code.Role = *req.Role
+	}
+
+	if req.ClearExpiry {
+		code.ExpiresAt = nil
+	} else if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(time.Now()) {
+			return nil, errors.New("expiry must be in the future")
+		}
+		code.ExpiresAt = req.ExpiresAt
+	}
+
+	if err := s.accessCodeRepo.Update(code); err != nil {
+		return nil, err
+	}
+
+	return code, nil
+}
+
+// DeleteAccessCode kodu iptal eder, bu kodla alınmış erişim tokenleri de geçersiz olur
+func (s *EventService) DeleteAccessCode(eventID uint, userID uint, codeID uint) error {
+	if _, err := s.getOwnedEvent(eventID, userID); err != nil {
+		return err
+	}
+
+	code, err := s.getEventAccessCode(eventID, codeID)
+	if err != nil {
+		return err
+	}
+
+	return s.accessCodeRepo.Delete(code.ID)
+}
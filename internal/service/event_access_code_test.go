@@ -0,0 +1,24 @@
+package service
+
+import "testing"
+
+func TestAccessCodeLookup(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	lookup := accessCodeLookup("PHOTO2024")
+	if len(lookup) != 64 {
+		t.Fatalf("lookup length = %d, want 64", len(lookup))
+	}
+
+	if again := accessCodeLookup("PHOTO2024"); again != lookup {
+		t.Errorf("lookup is not stable: %q != %q", again, lookup)
+	}
+	if other := accessCodeLookup("photo2024"); other == lookup {
+		t.Errorf("codes differing in case produced the same lookup")
+	}
+
+	t.Setenv("JWT_SECRET", "another-secret")
+	if rotated := accessCodeLookup("PHOTO2024"); rotated == lookup {
+		t.Errorf("lookup does not depend on the server secret")
+	}
+}
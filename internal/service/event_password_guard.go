@@ -0,0 +1,123 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/repository"
+)
+
+// Etkinlik parolası deneme sınırları. Gecikme ve kilit denemeyi yapan istemciye uygulanır, böylece
+// parola tahmin eden biri etkinliği diğer misafirler için kilitleyemez.
+const (
+	passwordAttemptWindow    = time.Hour        // Bu süre boyunca başarısız deneme olmazsa sayaç sıfırlanır
+	passwordFreeAttempts     = 5                // Gecikme uygulanmadan yapılabilecek başarısız deneme
+	passwordMaxDelay         = time.Minute      // Kademeli gecikmenin üst sınırı
+	passwordLockoutThreshold = 20               // Bu kadar başarısız denemeden sonra istemci kilitlenir
+	passwordLockoutDuration  = 15 * time.Minute // Kilit süresi
+)
+
+// Etkinlik genelindeki deneme sınırları. Çok sayıda istemciden gelen tahminleri durdurmak için
+// gecikme eşikten sonra kademeli olarak artar ve sonunda etkinlik geçici olarak kilitlenir.
+const (
+	passwordOwnerNotifyThreshold   = 20               // Etkinlik genelinde bu kadar başarısız denemede sahibine bildirim gönderilir
+	passwordEventSlowdownThreshold = 100              // Bu kadar başarısız denemeden sonra tüm denemeler yavaşlatılır
+	passwordEventSlowdown          = 2 * time.Second  // Etkinlik genelindeki ilk gecikme
+	passwordEventEscalationStep    = 20               // Gecikme her bu kadar başarısız denemede iki katına çıkar
+	passwordEventMaxDelay          = time.Minute      // Etkinlik genelindeki kademeli gecikmenin üst sınırı
+	passwordEventLockoutThreshold  = 300              // Bu kadar başarısız denemeden sonra etkinlik kilitlenir
+	passwordEventLockoutDuration   = 15 * time.Minute // Etkinlik genelindeki kilit süresi
+)
+
+// PasswordLockedError etkinlik parola denemelerinin geçici olarak durdurulduğunu belirtir
+type PasswordLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *PasswordLockedError) Error() string {
+	return "too many failed attempts"
+}
+
+// passwordFailureDelay istemcinin başarısız deneme sayısına göre bir sonraki denemeye kadar beklenecek süreyi döndürür
+func passwordFailureDelay(failedCount int) time.Duration {
+	if failedCount >= passwordLockoutThreshold {
+		return passwordLockoutDuration
+	}
+	if failedCount < passwordFreeAttempts {
+		return 0
+	}
+
+	delay := time.Second << uint(failedCount-passwordFreeAttempts)
+	if delay > passwordMaxDelay {
+		return passwordMaxDelay
+	}
+	return delay
+}
+
+// passwordEventDelay etkinlik genelindeki başarısız deneme sayısına göre tüm istemcilere uygulanan gecikmeyi döndürür
+func passwordEventDelay(failedCount int) time.Duration {
+	if failedCount >= passwordEventLockoutThreshold {
+		return passwordEventLockoutDuration
+	}
+	if failedCount < passwordEventSlowdownThreshold {
+		return 0
+	}
+
+	steps := (failedCount - passwordEventSlowdownThreshold) / passwordEventEscalationStep
+	delay := passwordEventSlowdown << uint(steps)
+	if delay > passwordEventMaxDelay {
+		return passwordEventMaxDelay
+	}
+	return delay
+}
+
+// claimPasswordAttempt parola karşılaştırılmadan önce denemeyi istemci ve etkinlik sayaçlarına
+// işler. Kilit kararı sayaçla aynı işlemde verildiğinden eşzamanlı denemeler kilidi atlayamaz.
+// Etkinlik genelindeki başarısız deneme sayısını döndürür.
+func (s *EventService) claimPasswordAttempt(eventID uint, ipHash string, now time.Time) (int, error) {
+	windowStart := now.Add(-passwordAttemptWindow)
+
+	eventAttempt, err := s.attemptRepo.ClaimAttempt(eventID, ipHash, now, windowStart, passwordFailureDelay, passwordEventDelay)
+	if err != nil {
+		var locked *repository.AttemptLockedError
+		if errors.As(err, &locked) {
+			return 0, &PasswordLockedError{RetryAfter: locked.LockedUntil.Sub(now)}
+		}
+		return 0, err
+	}
+	return eventAttempt.FailedCount, nil
+}
+
+// releasePasswordAttempt doğru parola veya erişim koduyla sonuçlanan denemeyi sayaçlardan geri alır
+func (s *EventService) releasePasswordAttempt(eventID uint, ipHash string) {
+	if err := s.attemptRepo.ReleaseAttempt(eventID, ipHash); err != nil {
+		fmt.Printf("Error releasing password attempt for event %d: %v\n", eventID, err)
+	}
+}
+
+// notifyFailedPasswords etkinlik genelinde eşiğe ulaşıldığında etkinlik sahibini bilgilendirir
+func (s *EventService) notifyFailedPasswords(event *models.Event, failedCount int, now time.Time) {
+	if failedCount < passwordOwnerNotifyThreshold {
+		return
+	}
+
+	// Bildirim pencere başına bir kez gönderilir
+	notify, err := s.attemptRepo.MarkOwnerNotified(event.ID, now, now.Add(-passwordAttemptWindow))
+	if err != nil || !notify {
+		return
+	}
+
+	owner, err := s.userRepo.GetByID(event.UserID)
+	if err != nil {
+		return
+	}
+
+	go func() {
+		if err := s.emailService.SendEventPasswordLockoutEmail(owner.Email, owner.FullName, event.Title, event.URL,
+			failedCount); err != nil {
+			fmt.Printf("Error sending password lockout email for event %d: %v\n", event.ID, err)
+		}
+	}()
+}
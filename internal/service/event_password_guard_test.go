@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPasswordFailureDelay(t *testing.T) {
+	tests := []struct {
+		failedCount int
+		want        time.Duration
+	}{
+		{0, 0},
+		{1, 0},
+		{passwordFreeAttempts - 1, 0},
+		{passwordFreeAttempts, time.Second},
+		{passwordFreeAttempts + 1, 2 * time.Second},
+		{passwordFreeAttempts + 2, 4 * time.Second},
+		{passwordFreeAttempts + 5, 32 * time.Second},
+		{passwordFreeAttempts + 6, passwordMaxDelay},
+		{passwordLockoutThreshold - 1, passwordMaxDelay},
+		{passwordLockoutThreshold, passwordLockoutDuration},
+		{passwordLockoutThreshold + 100, passwordLockoutDuration},
+	}
+
+	for _, tt := range tests {
+		if got := passwordFailureDelay(tt.failedCount); got != tt.want {
+			t.Errorf("passwordFailureDelay(%d) = %v, want %v", tt.failedCount, got, tt.want)
+		}
+	}
+}
+
+func TestPasswordEventDelay(t *testing.T) {
+	tests := []struct {
+		failedCount int
+		want        time.Duration
+	}{
+		{0, 0},
+		{passwordLockoutThreshold, 0},
+		{passwordEventSlowdownThreshold - 1, 0},
+		{passwordEventSlowdownThreshold, passwordEventSlowdown},
+		{passwordEventSlowdownThreshold + passwordEventEscalationStep - 1, passwordEventSlowdown},
+		{passwordEventSlowdownThreshold + passwordEventEscalationStep, 2 * passwordEventSlowdown},
+		{passwordEventSlowdownThreshold + 3*passwordEventEscalationStep, 8 * passwordEventSlowdown},
+		{passwordEventSlowdownThreshold + 5*passwordEventEscalationStep, passwordEventMaxDelay},
+		{passwordEventLockoutThreshold - 1, passwordEventMaxDelay},
+		{passwordEventLockoutThreshold, passwordEventLockoutDuration},
+		{passwordEventLockoutThreshold * 10, passwordEventLockoutDuration},
+	}
+
+	for _, tt := range tests {
+		if got := passwordEventDelay(tt.failedCount); got != tt.want {
+			t.Errorf("passwordEventDelay(%d) = %v, want %v", tt.failedCount, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/pkg/pdf"
+	"github.com/sefazor/ourphotos-backend/pkg/qrcode"
+)
+
+// printPageSizes baskı düzenlerinin punto cinsinden (genişlik, yükseklik) boyutları
+var printPageSizes = map[string][2]float64{
+	models.PrintLayoutA4:     {595.28, 841.89},
+	models.PrintLayoutA5:     {419.53, 595.28},
+	models.PrintLayoutLetter: {612, 792},
+	models.PrintLayoutCard:   {288, 432},
+}
+
+// printSheetMargin yazıcıların basamadığı kenar boşluğu, masa kartları bu alanın içine dizilir
+const printSheetMargin = 9
+
+var (
+	printTextColor  = color.RGBA{R: 0x22, G: 0x22, B: 0x22, A: 0xff}
+	printMutedColor = color.RGBA{R: 0x66, G: 0x66, B: 0x66, A: 0xff}
+	printCutColor   = color.RGBA{R: 0xbb, G: 0xbb, B: 0xbb, A: 0xff}
+	printAccent     = color.RGBA{R: 0x22, G: 0x22, B: 0x22, A: 0xff}
+)
+
+// GetEventPrintPDF etkinlik için başlık, karşılama metni, QR kod, kısa bağlantı ve yükleme
+// talimatlarını içeren baskıya hazır bir afiş veya masa kartı PDF'i oluşturur.
+func (s *EventService) GetEventPrintPDF(eventID uint, printOpts models.PrintOptions, opts qrcode.Options, withLogo bool) ([]byte, error) {
+	size, ok := printPageSizes[printOpts.Layout]
+	if !ok {
+		return nil, errors.New("invalid print layout")
+	}
+
+	if printOpts.Copies < 1 {
+		printOpts.Copies = 1
+	}
+	if printOpts.Layout != models.PrintLayoutCard && (printOpts.Sheet != "" || printOpts.Copies > 1) {
+		return nil, errors.New("multiple copies are only supported for table cards")
+	}
+	if printOpts.Copies > models.MaxPrintCopies {
+		return nil, fmt.Errorf("at most %d copies can be printed at once", models.MaxPrintCopies)
+	}
+
+	// Etkinliği getir
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found: %w", err)
+	}
+
+	if opts, err = s.qrOptions(event, opts, withLogo); err != nil {
+		return nil, err
+	}
+
+	code, err := s.qrService.New(event.URL, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate QR code: %w", err)
+	}
+
+	doc := pdf.New()
+	width, height := size[0], size[1]
+
+	if printOpts.Sheet == "" {
+		for i := 0; i < printOpts.Copies; i++ {
+			page := doc.AddPage(width, height)
+			if err := s.drawPrintCard(doc, page, code, event, 0, 0, width, height); err != nil {
+				return nil, err
+			}
+		}
+		return doc.Bytes()
+	}
+
+	// Masa kartlarını kağıda ızgara şeklinde diz
+	sheet, ok := printPageSizes[printOpts.Sheet]
+	if !ok || printOpts.Sheet == models.PrintLayoutCard || printOpts.Sheet == models.PrintLayoutA5 {
+		return nil, errors.New("invalid sheet size, must be a4 or letter")
+	}
+
+	cols := int(math.Floor((sheet[0] - 2*printSheetMargin) / width))
+	rows := int(math.Floor((sheet[1] - 2*printSheetMargin) / height))
+	perSheet := cols * rows
+	offsetX := (sheet[0] - float64(cols)*width) / 2
+	offsetY := (sheet[1] - float64(rows)*height) / 2
+
+	var page *pdf.Page
+	for i := 0; i < printOpts.Copies; i++ {
+		if i%perSheet == 0 {
+			page = doc.AddPage(sheet[0], sheet[1])
+		}
+
+		slot := i % perSheet
+		x := offsetX + float64(slot%cols)*width
+		y := offsetY + float64(slot/cols)*height
+		if err := s.drawPrintCard(doc, page, code, event, x, y, width, height); err != nil {
+			return nil, err
+		}
+
+		// Kesim çizgisi
+		page.SetStrokeColor(printCutColor)
+		page.StrokeRect(x, y, width, height, 0.5)
+	}
+
+	return doc.Bytes()
+}
+
+// drawPrintCard afiş içeriğini sol üst köşesi (x, y) olan width x height alanına çizer.
+// Tüm ölçüler alan genişliğine göre oranlanır, böylece aynı tasarım afiş ve masa kartında kullanılır.
+func (s *EventService) drawPrintCard(doc *pdf.Document, page *pdf.Page, code *qrcode.Code, event *models.Event, x, y, width, height float64) error {
+	margin := width * 0.08
+	inner := width - 2*margin
+	gap := width * 0.035
+
+	titleSize := width * 0.06
+	textSize := width * 0.028
+	headingSize := width * 0.036
+	urlSize := width * 0.032
+	stepSize := width * 0.025
+
+	title := limitLines(pdf.WrapText(pdf.FontBold, titleSize, event.Title, inner), 3)
+
+	welcome := strings.TrimSpace(event.WelcomeMessage)
+	if welcome == "" {
+		welcome = "We'd love to see the moments you capture today. Share your photos with us!"
+	}
+	welcomeLines := limitLines(pdf.WrapText(pdf.FontRegular, textSize, welcome, inner), 4)
+
+	heading := "Scan to share your photos"
+	steps := []string{
+		"1. Open your phone's camera and point it at the QR code",
+		"2. Tap the link to open the event gallery",
+		"3. Upload your photos, no app or account needed",
+	}
+	if !event.AllowGuestUploads {
+		heading = "Scan to view the photo gallery"
+		steps = []string{
+			"1. Open your phone's camera and point it at the QR code",
+			"2. Tap the link to open the event gallery",
+		}
+	}
+	if event.HasPassword {
+		steps = append(steps, fmt.Sprintf("%d. Enter the event password given by the host", len(steps)+1))
+	}
+
+	var stepLines []string
+	for _, step := range steps {
+		stepLines = append(stepLines, pdf.WrapText(pdf.FontRegular, stepSize, step, inner)...)
+	}
+
+	shortURL := s.qrService.EventURL(event.URL)
+	shortURL = strings.TrimPrefix(strings.TrimPrefix(shortURL, "https://"), "http://")
+
+	// QR kod metinlerden kalan alana sığacak şekilde boyutlandırılır
+	band := width * 0.02
+	textHeight := band + float64(len(title))*titleSize*1.2 + float64(len(welcomeLines))*textSize*1.4 +
+		headingSize*1.2 + urlSize*1.2 + float64(len(stepLines))*stepSize*1.5 + 5*gap
+	qrSize := math.Min(width*0.5, height-2*margin-textHeight)
+	if qrSize < width*0.25 {
+		qrSize = width * 0.25
+	}
+
+	// İçeriği dikey olarak ortala
+	cursor := y + math.Max(margin, (height-textHeight-qrSize)/2)
+
+	accent := color.Color(printAccent)
+	if c, err := qrcode.ParseHexColor(event.AccentColor); err == nil {
+		accent = c
+	}
+	page.SetFillColor(accent)
+	page.Rect(x+width*0.35, cursor, width*0.3, band)
+	cursor += band + gap
+
+	page.SetFillColor(printTextColor)
+	for _, line := range title {
+		page.CenteredText(pdf.FontBold, titleSize, x+margin, cursor, inner, line)
+		cursor += titleSize * 1.2
+	}
+	cursor += gap
+
+	page.SetFillColor(printMutedColor)
+	for _, line := range welcomeLines {
+		page.CenteredText(pdf.FontRegular, textSize, x+margin, cursor, inner, line)
+		cursor += textSize * 1.4
+	}
+	cursor += gap
+
+	if err := code.DrawPDF(doc, page, x+(width-qrSize)/2, cursor, qrSize); err != nil {
+		return err
+	}
+	cursor += qrSize + gap
+
+	page.SetFillColor(printTextColor)
+	page.CenteredText(pdf.FontBold, headingSize, x+margin, cursor, inner, heading)
+	cursor += headingSize*1.2 + gap/2
+
+	page.SetFillColor(accent)
+	page.CenteredText(pdf.FontBold, urlSize, x+margin, cursor, inner, shortURL)
+	cursor += urlSize*1.2 + gap
+
+	page.SetFillColor(printMutedColor)
+	for _, line := range stepLines {
+		page.CenteredText(pdf.FontRegular, stepSize, x+margin, cursor, inner, line)
+		cursor += stepSize * 1.5
+	}
+
+	return nil
+}
+
+// limitLines satır sayısını max ile sınırlar, kesilen metnin sonuna üç nokta ekler
+func limitLines(lines []string, max int) []string {
+	if len(lines) <= max {
+		return lines
+	}
+	lines = lines[:max]
+	lines[max-1] = strings.TrimRight(lines[max-1], " .,") + "..."
+	return lines
+}
@@ -1,39 +1,76 @@
 package service
 
 import (
+	"bytes"
 	cryptorand "crypto/rand"
 	"errors"
 	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
 	"math/rand"
 	"mime/multipart"
+	"net/http"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/sefazor/ourphotos-backend/internal/models"
 	"github.com/sefazor/ourphotos-backend/internal/repository"
 	"github.com/sefazor/ourphotos-backend/pkg/bcrypt"
+	"github.com/sefazor/ourphotos-backend/pkg/email"
+	jwtPkg "github.com/sefazor/ourphotos-backend/pkg/jwt"
 	"github.com/sefazor/ourphotos-backend/pkg/qrcode"
 )
 
 var r = rand.New(rand.NewSource(time.Now().UnixNano()))
 
 type EventService struct {
-	eventRepo    *repository.EventRepository
-	userRepo     *repository.UserRepository
-	photoService *PhotoService
-	qrService    *qrcode.QRService
+	eventRepo      *repository.EventRepository
+	userRepo       *repository.UserRepository
+	purgeLogRepo   *repository.PurgeLogRepository
+	templateRepo   *repository.EventTemplateRepository
+	attemptRepo    *repository.PasswordAttemptRepository
+	accessCodeRepo *repository.AccessCodeRepository
+	photoService   *PhotoService
+	qrService      *qrcode.QRService
+	emailService   *email.EmailService
+	warningDays    []int         // Büyükten küçüğe sıralı süre uyarısı eşikleri
+	gracePeriod    time.Duration // Süresi dolan etkinliğin silinmeden önce salt okunur kaldığı süre
 }
 
 func NewEventService(
 	eventRepo *repository.EventRepository,
 	userRepo *repository.UserRepository,
+	purgeLogRepo *repository.PurgeLogRepository,
+	templateRepo *repository.EventTemplateRepository,
+	attemptRepo *repository.PasswordAttemptRepository,
+	accessCodeRepo *repository.AccessCodeRepository,
 	photoService *PhotoService,
 	qrService *qrcode.QRService,
+	emailService *email.EmailService,
+	warningDays []int,
+	graceDays int,
 ) *EventService {
+	thresholds := append([]int(nil), warningDays...)
+	sort.Sort(sort.Reverse(sort.IntSlice(thresholds)))
+
 	return &EventService{
-		eventRepo:    eventRepo,
-		userRepo:     userRepo,
-		photoService: photoService,
-		qrService:    qrService,
+		eventRepo:      eventRepo,
+		userRepo:       userRepo,
+		purgeLogRepo:   purgeLogRepo,
+		templateRepo:   templateRepo,
+		attemptRepo:    attemptRepo,
+		accessCodeRepo: accessCodeRepo,
+		photoService:   photoService,
+		qrService:      qrService,
+		emailService:   emailService,
+		warningDays:    thresholds,
+		gracePeriod:    time.Duration(graceDays) * 24 * time.Hour,
 	}
 }
 
@@ -65,22 +102,25 @@ func generateEventURL() string {
 
 // Süreye göre son geçerlilik tarihini hesaplayan yardımcı fonksiyon
 func calculateExpiryDate(duration models.DurationType) time.Time {
-	now := time.Now()
+	return expiryDateFrom(time.Now(), duration)
+}
 
+// expiryDateFrom verilen başlangıçtan itibaren süreye göre son geçerlilik tarihini hesaplar
+func expiryDateFrom(start time.Time, duration models.DurationType) time.Time {
 	switch duration {
 	case models.Duration7Days:
-		return now.AddDate(0, 0, 7)
+		return start.AddDate(0, 0, 7)
 	case models.Duration14Days:
-		return now.AddDate(0, 0, 14)
+		return start.AddDate(0, 0, 14)
 	case models.Duration21Days:
-		return now.AddDate(0, 0, 21)
+		return start.AddDate(0, 0, 21)
 	case models.Duration30Days:
-		return now.AddDate(0, 0, 30)
+		return start.AddDate(0, 0, 30)
 	case models.Duration3Months:
-		return now.AddDate(0, 3, 0)
+		return start.AddDate(0, 3, 0)
 	default:
 		// Varsayılan olarak 7 gün
-		return now.AddDate(0, 0, 7)
+		return start.AddDate(0, 0, 7)
 	}
 }
 
@@ -96,47 +136,81 @@ func (s *EventService) CreateEvent(userID uint, req models.EventRequest) (*model
 		return nil, errors.New("event limit exceeded")
 	}
 
-	// URL generate et
-	url := generateEventURL()
-
-	// URL unique olana kadar dene
-	for {
-		exists, _ := s.eventRepo.URLExists(url)
-		if !exists {
-			break
+	// Şablon seçildiyse boş bırakılan alanları şablondan doldur
+	var template *models.EventTemplate
+	if req.TemplateID != nil {
+		template, err = s.getUserTemplate(*req.TemplateID, userID)
+		if err != nil {
+			return nil, err
 		}
-		url = generateEventURL()
+		applyEventTemplate(&req, template)
 	}
 
-	// Şifre varsa hashle
+	// Şifre varsa hashle, şifre girilmediyse şablonun şifresi kullanılır
+	hasPassword := boolOrDefault(req.HasPassword, false)
 	var hashedPassword string
-	if req.HasPassword && req.Password != "" {
+	if hasPassword && req.Password != "" {
 		var err error
 		hashedPassword, err = bcrypt.HashPassword(req.Password)
 		if err != nil {
 			return nil, fmt.Errorf("failed to hash password: %w", err)
 		}
+	} else if hasPassword && template != nil && template.HasPassword {
+		hashedPassword = template.Password
 	}
 
 	// Süreye göre son geçerlilik tarihini hesapla
 	expiresAt := calculateExpiryDate(req.Duration)
 
+	if err := validateUploadWindow(req.UploadOpensAt, req.UploadClosesAt, &expiresAt); err != nil {
+		return nil, err
+	}
+
 	// Event modelini oluştur
 	event := &models.Event{
 		UserID:            userID,
 		Title:             req.Title,
 		Description:       req.Description,
 		Location:          req.Location,
-		URL:               url,
-		IsPublic:          req.IsPublic,
-		HasPassword:       req.HasPassword,
+		IsPublic:          boolOrDefault(req.IsPublic, true),
+		HasPassword:       hasPassword,
 		Password:          hashedPassword,
-		AllowGuestUploads: req.AllowGuestUploads,
+		AllowGuestUploads: boolOrDefault(req.AllowGuestUploads, true),
+		CommentsDisabled:  boolOrDefault(req.CommentsDisabled, false),
+		GuestbookDisabled: boolOrDefault(req.GuestbookDisabled, false),
+		RequireApproval:   boolOrDefault(req.RequireApproval, false),
+		AccentColor:       req.AccentColor,
+		WelcomeMessage:    strings.TrimSpace(req.WelcomeMessage),
+		ThankYouMessage:   strings.TrimSpace(req.ThankYouMessage),
+		UploadOpensAt:     req.UploadOpensAt,
+		UploadClosesAt:    req.UploadClosesAt,
+		MaxPhotos:         req.MaxPhotos,
+		MaxPhotosPerGuest: req.MaxPhotosPerGuest,
+		Duration:          req.Duration,
 		ExpiresAt:         expiresAt,
-		CreatedAt:         time.Now(),
-		UpdatedAt:         time.Now(),
 	}
 
+	return s.createUserEvent(user, event)
+}
+
+// createUserEvent etkinliğe benzersiz bir URL atayıp kaydeder ve kullanıcının etkinlik limitinden düşer
+func (s *EventService) createUserEvent(user *models.User, event *models.Event) (*models.EventResponse, error) {
+	// URL generate et
+	url := generateEventURL()
+
+	// URL unique olana kadar dene
+	for {
+		taken, _ := s.eventURLTaken(url, 0)
+		if !taken {
+			break
+		}
+		url = generateEventURL()
+	}
+
+	event.URL = url
+	event.CreatedAt = time.Now()
+	event.UpdatedAt = time.Now()
+
 	// Eventi oluştur
 	createdEvent, err := s.eventRepo.Create(event)
 	if err != nil {
@@ -149,87 +223,352 @@ func (s *EventService) CreateEvent(userID uint, req models.EventRequest) (*model
 		return nil, err
 	}
 
-	// Kullanıcının kalan fotoğraf limitini hesapla
-	remainingPhotoLimit := user.PhotoLimit
+	// Response oluştur, kullanıcının kalan fotoğraf limitini ekle
+	response := s.BuildEventResponse(createdEvent)
+	applyOwnerPhotoLimit(&response, createdEvent, user.PhotoLimit)
 
-	// Response oluştur
-	response := &models.EventResponse{
-		ID:                      createdEvent.ID,
-		Title:                   createdEvent.Title,
-		Description:             createdEvent.Description,
-		Location:                createdEvent.Location,
-		URL:                     createdEvent.URL,
-		IsPublic:                createdEvent.IsPublic,
-		HasPassword:             createdEvent.HasPassword,
-		AllowGuestUploads:       createdEvent.AllowGuestUploads,
-		PhotoCount:              createdEvent.PhotoCount,
-		ExpiresAt:               createdEvent.ExpiresAt,
-		Duration:                string(req.Duration),
-		CreatedAt:               createdEvent.CreatedAt,
-		UpdatedAt:               createdEvent.UpdatedAt,
-		RemainingUserPhotoLimit: remainingPhotoLimit,
-		TotalAllocatedPhotos:    0,
+	return &response, nil
+}
+
+// DuplicateEvent etkinliğin ayarlarını fotoğraflar, albümler ve kapak olmadan yeni bir etkinliğe kopyalar
+func (s *EventService) DuplicateEvent(eventID uint, userID uint, req models.DuplicateEventRequest) (*models.EventResponse, error) {
+	source, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	if source.UserID != userID {
+		return nil, errors.New("unauthorized")
 	}
 
-	return response, nil
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.EventLimit <= 0 {
+		return nil, errors.New("event limit exceeded")
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = source.Title
+	}
+
+	duration := req.Duration
+	if duration == "" {
+		duration = source.Duration
+	}
+
+	// Yükleme penceresi tarihe bağlı olduğu için kopyalanmaz
+	event := &models.Event{
+		UserID:            userID,
+		Title:             title,
+		Description:       source.Description,
+		Location:          source.Location,
+		IsPublic:          source.IsPublic,
+		HasPassword:       source.HasPassword,
+		Password:          source.Password,
+		AllowGuestUploads: source.AllowGuestUploads,
+		CommentsDisabled:  source.CommentsDisabled,
+		GuestbookDisabled: source.GuestbookDisabled,
+		RequireApproval:   source.RequireApproval,
+		AccentColor:       source.AccentColor,
+		WelcomeMessage:    source.WelcomeMessage,
+		ThankYouMessage:   source.ThankYouMessage,
+		MaxPhotos:         source.MaxPhotos,
+		MaxPhotosPerGuest: source.MaxPhotosPerGuest,
+		Duration:          duration,
+		ExpiresAt:         calculateExpiryDate(duration),
+	}
+
+	return s.createUserEvent(user, event)
+}
+
+// BuildEventResponse etkinliği kapak görseli çözümlenmiş olarak response modeline dönüştürür
+func (s *EventService) BuildEventResponse(event *models.Event) models.EventResponse {
+	return s.buildEventResponse(event, s.coverImageURL(event))
+}
+
+// buildEventResponse etkinliği önceden çözümlenmiş kapak görseliyle response modeline dönüştürür
+func (s *EventService) buildEventResponse(event *models.Event, coverImageURL string) models.EventResponse {
+	return models.EventResponse{
+		ID:                event.ID,
+		Title:             event.Title,
+		Description:       event.Description,
+		Location:          event.Location,
+		URL:               event.URL,
+		IsPublic:          event.IsPublic,
+		HasPassword:       event.HasPassword,
+		AllowGuestUploads: event.AllowGuestUploads,
+		CommentsDisabled:  event.CommentsDisabled,
+		GuestbookDisabled: event.GuestbookDisabled,
+		RequireApproval:   event.RequireApproval,
+		CoverPhotoID:      event.CoverPhotoID,
+		CoverImageURL:     coverImageURL,
+		AccentColor:       event.AccentColor,
+		WelcomeMessage:    event.WelcomeMessage,
+		ThankYouMessage:   event.ThankYouMessage,
+		UploadOpensAt:     event.UploadOpensAt,
+		UploadClosesAt:    event.UploadClosesAt,
+		UploadStatus:      UploadStatus(event, time.Now()),
+		MaxPhotos:         event.MaxPhotos,
+		MaxPhotosPerGuest: event.MaxPhotosPerGuest,
+		PhotoCount:        event.PhotoCount,
+		Duration:          string(event.Duration),
+		ExpiresAt:         event.ExpiresAt,
+		IsExpired:         IsEventExpired(event, time.Now()),
+		IsPermanent:       event.IsPermanent,
+		PurgeAt:           event.ExpiresAt.Add(s.gracePeriod),
+		CreatedAt:         event.CreatedAt,
+		UpdatedAt:         event.UpdatedAt,
+	}
+}
+
+// IsEventExpired etkinlik süresinin dolup dolmadığını döndürür, süresi dolan etkinlik salt okunurdur
+func IsEventExpired(event *models.Event, now time.Time) bool {
+	if event.IsPermanent {
+		return false
+	}
+	return event.IsExpired || now.After(event.ExpiresAt)
+}
+
+// applyOwnerPhotoLimit etkinlik sahibinin kalan fotoğraf limitini ve etkinliğe ayrılan toplam
+// fotoğraf sayısını response'a ekler. Etkinliğin kendi sınırı varsa ayrılan sayı bu sınırdır.
+// Sınır yoksa mevcut fotoğraflara sahibin kalan limiti eklenir; bu kalan limit etkinliğe özel
+// değildir, sahibin tüm etkinlikleri arasında paylaşılır.
+func applyOwnerPhotoLimit(response *models.EventResponse, event *models.Event, remainingLimit int) {
+	response.RemainingUserPhotoLimit = remainingLimit
+
+	if event.MaxPhotos > 0 {
+		response.TotalAllocatedPhotos = event.MaxPhotos
+		return
+	}
+	response.TotalAllocatedPhotos = event.PhotoCount + remainingLimit
+}
+
+// durationChangeExpiry süre değişikliğinden sonraki son geçerlilik tarihini döndürür. Süre
+// oluşturma anından itibaren hesaplanır ve mevcut bitiş tarihini geçemez; süre uzatma satın
+// alınır. Süresi dolan etkinliğin süresi değiştirilemez.
+func durationChangeExpiry(event *models.Event, duration models.DurationType, now time.Time) (time.Time, error) {
+	if IsEventExpired(event, now) {
+		return time.Time{}, errors.New("duration of an expired event cannot be changed")
+	}
+
+	expiresAt := expiryDateFrom(event.CreatedAt, duration)
+	if expiresAt.After(event.ExpiresAt) {
+		return time.Time{}, errors.New("duration cannot extend the event, purchase an extension instead")
+	}
+	if !expiresAt.After(now) {
+		return time.Time{}, errors.New("duration has already elapsed since the event was created")
+	}
+	return expiresAt, nil
 }
 
-func (s *EventService) CheckEventPassword(eventURL string, password string) error {
+// UploadStatus etkinliğin misafir yükleme penceresine göre verilen andaki yükleme durumunu döndürür
+func UploadStatus(event *models.Event, now time.Time) string {
+	switch {
+	case IsEventExpired(event, now):
+		return models.UploadStatusExpired
+	case !event.AllowGuestUploads:
+		return models.UploadStatusDisabled
+	case event.UploadOpensAt != nil && now.Before(*event.UploadOpensAt):
+		return models.UploadStatusScheduled
+	case event.UploadClosesAt != nil && !now.Before(*event.UploadClosesAt):
+		return models.UploadStatusClosed
+	}
+	return models.UploadStatusOpen
+}
+
+// validateUploadWindow yükleme penceresinin tutarlı ve etkinlik süresi içinde olduğunu kontrol eder.
+// expiresAt boşsa etkinlik kalıcı arşivdedir ve pencere sadece kendi içinde doğrulanır.
+func validateUploadWindow(opensAt, closesAt *time.Time, expiresAt *time.Time) error {
+	if opensAt != nil && closesAt != nil && !closesAt.After(*opensAt) {
+		return errors.New("upload window must close after it opens")
+	}
+	if expiresAt == nil {
+		return nil
+	}
+	if opensAt != nil && !opensAt.Before(*expiresAt) {
+		return errors.New("upload window must open before the event expires")
+	}
+	if closesAt != nil && closesAt.After(*expiresAt) {
+		return errors.New("upload window must close before the event expires")
+	}
+	return nil
+}
+
+// coverImageURL yüklenen kapak görselini, yoksa etkinlik fotoğraflarından seçilen kapağı döndürür
+func (s *EventService) coverImageURL(event *models.Event) string {
+	if event.CoverImageID != "" {
+		return s.photoService.ImgStorage.GetPublicURL(event.CoverImageID)
+	}
+
+	if event.CoverPhotoID == nil {
+		return ""
+	}
+
+	photo, err := s.photoService.photoRepo.GetByID(*event.CoverPhotoID)
+	if err != nil {
+		return ""
+	}
+	return coverPhotoURL(photo)
+}
+
+// coverImageURLs etkinlik listesinin kapak görsellerini kapak fotoğraflarını tek sorguda
+// yükleyerek çözümler. Sonuç etkinlik ID'sine göre döner.
+func (s *EventService) coverImageURLs(events []models.Event) map[uint]string {
+	urls := make(map[uint]string, len(events))
+
+	var photoIDs []uint
+	for i := range events {
+		if events[i].CoverImageID != "" {
+			urls[events[i].ID] = s.photoService.ImgStorage.GetPublicURL(events[i].CoverImageID)
+		} else if events[i].CoverPhotoID != nil {
+			photoIDs = append(photoIDs, *events[i].CoverPhotoID)
+		}
+	}
+
+	if len(photoIDs) == 0 {
+		return urls
+	}
+
+	photos, err := s.photoService.photoRepo.GetByIDs(photoIDs)
+	if err != nil {
+		fmt.Printf("Failed to load cover photos: %v\n", err)
+		return urls
+	}
+
+	photosByID := make(map[uint]*models.Photos, len(photos))
+	for i := range photos {
+		photosByID[photos[i].ID] = &photos[i]
+	}
+
+	for i := range events {
+		if events[i].CoverImageID != "" || events[i].CoverPhotoID == nil {
+			continue
+		}
+		if photo, ok := photosByID[*events[i].CoverPhotoID]; ok {
+			urls[events[i].ID] = coverPhotoURL(photo)
+		}
+	}
+
+	return urls
+}
+
+// coverPhotoURL kapak olarak seçilen fotoğrafın adresini döndürür.
+// Gizlenen veya onay bekleyen fotoğraflar kapak olarak gösterilmez.
+func coverPhotoURL(photo *models.Photos) string {
+	if photo.Hidden() || photo.PendingApproval {
+		return ""
+	}
+	return photo.PublicURL
+}
+
+// CheckEventPassword etkinlik parolasını veya aktif erişim kodlarından birini doğrular ve
+// etkinliğin güncel parola versiyonuna bağlı, rol bilgisi taşıyan imzalı erişim tokeni verir.
+// Başarısız denemeler clientIP'nin hash'i ile istemci bazında sınırlandırılır.
+func (s *EventService) CheckEventPassword(eventURL string, password string, clientIP string) (*models.EventAccessResponse, error) {
 	event, err := s.eventRepo.GetByURL(eventURL)
 	if err != nil {
-		return err
+		return nil, errors.New("event not found")
 	}
 
-	if !event.HasPassword {
-		return nil
+	// Etkinlik parolası yükleme yetkisi verir, erişim kodu ise kendi rolünü taşır
+	role := models.AccessRoleUpload
+	var codeID uint
+
+	// Gecikme veya kilit süresi dolmadan doğru parola da kabul edilmez. Deneme karşılaştırmadan
+	// önce sayılır ve doğru parolada geri alınır.
+	if event.HasPassword {
+		now := time.Now()
+		ipHash := hashDeviceToken(clientIP)
+		failedCount, err := s.claimPasswordAttempt(event.ID, ipHash, now)
+		if err != nil {
+			return nil, err
+		}
+
+		// Hash karşılaştırması yap
+		if err := bcrypt.ComparePassword(event.Password, password); err != nil {
+			code := s.matchAccessCode(event.ID, password, now)
+			if code == nil {
+				s.notifyFailedPasswords(event, failedCount, now)
+				return nil, errors.New("incorrect password")
+			}
+
+			if err := s.accessCodeRepo.RecordUsage(code.ID, now); err != nil {
+				fmt.Printf("Failed to record usage of access code %d: %v\n", code.ID, err)
+			}
+			role = code.Role
+			codeID = code.ID
+		}
+
+		s.releasePasswordAttempt(event.ID, ipHash)
 	}
 
-	// Hash karşılaştırması yap
-	if err := bcrypt.ComparePassword(event.Password, password); err != nil {
-		return errors.New("incorrect password")
+	token, err := jwtPkg.GenerateEventAccessToken(event.ID, event.PasswordVersion, codeID)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &models.EventAccessResponse{
+		AccessToken: token,
+		Role:        role,
+		ExpiresAt:   time.Now().Add(jwtPkg.TokenExpiryEventAccess),
+	}, nil
 }
 
 func (s *EventService) GetEvent(eventID uint) (*models.Event, error) {
 	return s.eventRepo.GetByID(eventID)
 }
 
-func (s *EventService) GetUserEvents(userID uint) ([]models.EventResponse, error) {
-	events, err := s.eventRepo.GetByUserID(userID)
+// GetUserEvents kullanıcının etkinliklerini arama, filtre ve sıralama ayarlarına göre sayfalı olarak döndürür
+func (s *EventService) GetUserEvents(userID uint, query models.EventListQuery) ([]models.EventResponse, int64, error) {
+	query.Search = strings.TrimSpace(query.Search)
+
+	switch query.Status {
+	case "", models.EventStatusActive, models.EventStatusExpired:
+	default:
+		return nil, 0, errors.New("invalid status filter")
+	}
+
+	if query.Sort == "" {
+		query.Sort = models.EventSortCreated
+	}
+	switch query.Sort {
+	case models.EventSortCreated, models.EventSortExpiry, models.EventSortPhotos:
+	default:
+		return nil, 0, errors.New("invalid sort field")
+	}
+
+	if query.Order == "" {
+		query.Order = "desc"
+	}
+	if query.Order != "asc" && query.Order != "desc" {
+		return nil, 0, errors.New("invalid sort order")
+	}
+
+	events, total, err := s.eventRepo.ListByUserID(userID, query, time.Now())
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	remainingLimit := user.PhotoLimit
+	coverURLs := s.coverImageURLs(events)
 
-	var response []models.EventResponse
-	for _, event := range events {
-		response = append(response, models.EventResponse{
-			ID:                      event.ID,
-			Title:                   event.Title,
-			Description:             event.Description,
-			Location:                event.Location,
-			URL:                     event.URL,
-			IsPublic:                event.IsPublic,
-			HasPassword:             event.HasPassword,
-			AllowGuestUploads:       event.AllowGuestUploads,
-			PhotoCount:              event.PhotoCount,
-			ExpiresAt:               event.ExpiresAt,
-			CreatedAt:               event.CreatedAt,
-			UpdatedAt:               event.UpdatedAt,
-			RemainingUserPhotoLimit: remainingLimit,
-			TotalAllocatedPhotos:    0,
-		})
-	}
-
-	return response, nil
+	response := make([]models.EventResponse, 0, len(events))
+	for i := range events {
+		eventResponse := s.buildEventResponse(&events[i], coverURLs[events[i].ID])
+		applyOwnerPhotoLimit(&eventResponse, &events[i], remainingLimit)
+		response = append(response, eventResponse)
+	}
+
+	return response, total, nil
 }
 
 func (s *EventService) UpdateEvent(eventID uint, userID uint, req models.UpdateEventRequest) (*models.Event, error) {
@@ -260,8 +599,15 @@ func (s *EventService) UpdateEvent(eventID uint, userID uint, req models.UpdateE
 		updated = true
 	}
 	if req.Duration != nil {
-		// Süreye göre yeni son geçerlilik tarihini hesapla
-		event.ExpiresAt = calculateExpiryDate(*req.Duration)
+		expiresAt, err := durationChangeExpiry(event, *req.Duration, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		// Uyarılar yeni tarihe göre tekrar gönderilir
+		event.Duration = *req.Duration
+		event.ExpiresAt = expiresAt
+		event.ExpiryWarningDays = 0
 		updated = true
 	}
 	if req.IsPublic != nil {
@@ -270,9 +616,10 @@ func (s *EventService) UpdateEvent(eventID uint, userID uint, req models.UpdateE
 	}
 	if req.HasPassword != nil {
 		event.HasPassword = *req.HasPassword
-		// Şifre durumunu güncelle
+		// Şifre durumunu güncelle, parola değişince daha önce verilen erişim tokenleri geçersiz olur
 		if !event.HasPassword {
 			event.Password = ""
+			event.PasswordVersion++
 		} else if req.Password != nil && *req.Password != "" {
 			// Şifreyi hashle
 			hashedPassword, err := bcrypt.HashPassword(*req.Password)
@@ -280,6 +627,7 @@ func (s *EventService) UpdateEvent(eventID uint, userID uint, req models.UpdateE
 				return nil, fmt.Errorf("failed to hash password: %w", err)
 			}
 			event.Password = hashedPassword
+			event.PasswordVersion++
 		}
 		updated = true
 	}
@@ -287,6 +635,82 @@ func (s *EventService) UpdateEvent(eventID uint, userID uint, req models.UpdateE
 		event.AllowGuestUploads = *req.AllowGuestUploads
 		updated = true
 	}
+	if req.CommentsDisabled != nil {
+		event.CommentsDisabled = *req.CommentsDisabled
+		updated = true
+	}
+	if req.GuestbookDisabled != nil {
+		event.GuestbookDisabled = *req.GuestbookDisabled
+		updated = true
+	}
+	if req.RequireApproval != nil {
+		event.RequireApproval = *req.RequireApproval
+		updated = true
+	}
+	if req.CoverPhotoID != nil {
+		if *req.CoverPhotoID == 0 {
+			event.CoverPhotoID = nil
+		} else {
+			// Kapak sadece etkinliğin kendi fotoğraflarından seçilebilir
+			photo, err := s.photoService.photoRepo.GetByID(*req.CoverPhotoID)
+			if err != nil || photo.EventID != event.ID {
+				return nil, errors.New("cover photo not found")
+			}
+			event.CoverPhotoID = &photo.ID
+		}
+		updated = true
+	}
+	if req.AccentColor != nil {
+		event.AccentColor = *req.AccentColor
+		updated = true
+	}
+	if req.WelcomeMessage != nil {
+		event.WelcomeMessage = strings.TrimSpace(*req.WelcomeMessage)
+		updated = true
+	}
+	if req.ThankYouMessage != nil {
+		event.ThankYouMessage = strings.TrimSpace(*req.ThankYouMessage)
+		updated = true
+	}
+	if req.MaxPhotos != nil {
+		event.MaxPhotos = *req.MaxPhotos
+		updated = true
+	}
+	if req.MaxPhotosPerGuest != nil {
+		event.MaxPhotosPerGuest = *req.MaxPhotosPerGuest
+		updated = true
+	}
+	if req.ClearUploadWindow {
+		event.UploadOpensAt = nil
+		event.UploadClosesAt = nil
+		updated = true
+	}
+	if req.UploadOpensAt != nil {
+		event.UploadOpensAt = req.UploadOpensAt
+		updated = true
+	}
+	if req.UploadClosesAt != nil {
+		event.UploadClosesAt = req.UploadClosesAt
+		updated = true
+	}
+
+	// Süre veya pencere değiştiyse yükleme penceresini yeniden doğrula
+	if req.Duration != nil || req.UploadOpensAt != nil || req.UploadClosesAt != nil {
+		var expiresAt *time.Time
+		if !event.IsPermanent {
+			expiresAt = &event.ExpiresAt
+		}
+		if err := validateUploadWindow(event.UploadOpensAt, event.UploadClosesAt, expiresAt); err != nil {
+			return nil, err
+		}
+	}
+
+	// URL değişikliği ayrı bir işlemde kaydedildiğinden diğer alanlar doğrulandıktan sonra uygulanır
+	if req.URL != nil {
+		if err := s.changeEventURL(event, *req.URL); err != nil {
+			return nil, err
+		}
+	}
 
 	// Değişiklik yoksa güncelleme yapma
 	if !updated {
@@ -312,27 +736,8 @@ func (s *EventService) DeleteEvent(eventID uint, userID uint) error {
 		return errors.New("unauthorized")
 	}
 
-	// Önce etkinliğe ait fotoğrafları bul
-	photos, err := s.photoService.photoRepo.GetByEventID(eventID)
-	if err != nil {
-		return fmt.Errorf("failed to retrieve event photos: %w", err)
-	}
-
-	// Her bir fotoğrafı depolama servislerinden sil
-	for _, photo := range photos {
-		// Cloudflare Images'dan sil
-		if err := s.photoService.ImgStorage.Delete(photo.ImageID); err != nil {
-			// Hata loga kaydedilmeli ama işleme devam edilmeli
-			fmt.Printf("Error deleting photo %s from Cloudflare Images: %v\n", photo.ImageID, err)
-		}
-	}
-
-	// Veritabanından tüm fotoğrafları sil
-	if err := s.photoService.photoRepo.DeleteByEventID(eventID); err != nil {
-		return fmt.Errorf("failed to delete event photos from database: %w", err)
-	}
-
-	// Event'i sil
+	// Etkinliği çöp kutusuna taşı. Fotoğraflar etkinlikle birlikte geri yüklenebilmesi için olduğu gibi kalır,
+	// depolama servisinden silme saklama süresi dolunca PurgeEventContent ile yapılır.
 	if err := s.eventRepo.Delete(eventID); err != nil {
 		return err
 	}
@@ -351,6 +756,59 @@ func (s *EventService) GetEventByURL(url string) (*models.Event, error) {
 	return s.eventRepo.GetByURL(url)
 }
 
+// eventURLTaken URL'nin başka bir etkinlik tarafından kullanılıp kullanılmadığını kontrol eder.
+// Etkinliklerin eski URL'leri de yönlendirme için ayrıldığından dolu sayılır.
+func (s *EventService) eventURLTaken(url string, eventID uint) (bool, error) {
+	exists, err := s.eventRepo.URLExists(url)
+	if err != nil || exists {
+		return true, err
+	}
+
+	history, err := s.eventRepo.GetURLHistory(url)
+	if err == nil && history.EventID != eventID {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// changeEventURL etkinliğe özel URL atar, eski URL yeni adrese yönlendirilmek üzere geçmişe eklenir
+func (s *EventService) changeEventURL(event *models.Event, url string) error {
+	url = normalizeEventURL(url)
+	if url == event.URL {
+		return nil
+	}
+
+	if err := validateEventURL(url); err != nil {
+		return err
+	}
+
+	taken, err := s.eventURLTaken(url, event.ID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errors.New("url is already taken")
+	}
+
+	return s.eventRepo.ChangeURL(event, url)
+}
+
+// ResolveMovedURL URL'si değiştirilmiş bir etkinliğin eski URL'sinden güncel URL'sini bulur
+func (s *EventService) ResolveMovedURL(url string) (string, error) {
+	history, err := s.eventRepo.GetURLHistory(url)
+	if err != nil {
+		return "", errors.New("event not found")
+	}
+
+	event, err := s.eventRepo.GetByID(history.EventID)
+	if err != nil {
+		return "", errors.New("event not found")
+	}
+
+	return event.URL, nil
+}
+
 func (s *EventService) GetEventPhotoCount(eventID uint) (int64, error) {
 	return s.eventRepo.GetPhotoCount(eventID)
 }
@@ -369,13 +827,134 @@ func (s *EventService) UploadEventPhoto(eventID uint, userID uint, file *multipa
 
 	// Fotoğraf yükleme işlemi
 	// Bu kısmı PhotoService'e delege edebiliriz
-	return s.photoService.UploadPhoto(eventID, userID, file)
+	return s.photoService.UploadPhoto(eventID, userID, file, UploadOptions{})
+}
+
+// UploadCoverImage etkinlik için fotoğraf limitinden düşmeyen ayrı bir kapak görseli yükler
+func (s *EventService) UploadCoverImage(eventID uint, userID uint, file *multipart.FileHeader) (*models.EventResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	// Yetki kontrolü
+	if event.UserID != userID {
+		return nil, errors.New("unauthorized")
+	}
+
+	fileContent, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer fileContent.Close()
+
+	// Sadece desteklenen görsel formatlarını kabul et
+	header := make([]byte, 512)
+	n, _ := fileContent.Read(header)
+	switch http.DetectContentType(header[:n]) {
+	case "image/jpeg", "image/png", "image/gif", "image/webp":
+	default:
+		return nil, errors.New("unsupported image type")
+	}
+	if _, err := fileContent.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to seek file: %w", err)
+	}
+
+	imageID, _, err := s.photoService.ImgStorage.UploadWithFilename(fileContent, file.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	previousImageID := event.CoverImageID
+	event.CoverImageID = imageID
+	if err := s.eventRepo.Update(event); err != nil {
+		_ = s.photoService.ImgStorage.Delete(imageID)
+		return nil, err
+	}
+
+	// Eski kapak görselini depolama servisinden kaldır
+	if previousImageID != "" {
+		if err := s.photoService.ImgStorage.Delete(previousImageID); err != nil {
+			fmt.Printf("Error deleting previous cover image %s: %v\n", previousImageID, err)
+		}
+	}
+
+	response := s.BuildEventResponse(event)
+	return &response, nil
+}
+
+// RemoveCoverImage yüklenen kapak görselini ve seçili kapak fotoğrafını kaldırır
+func (s *EventService) RemoveCoverImage(eventID uint, userID uint) (*models.EventResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	// Yetki kontrolü
+	if event.UserID != userID {
+		return nil, errors.New("unauthorized")
+	}
+
+	if event.CoverImageID != "" {
+		if err := s.photoService.ImgStorage.Delete(event.CoverImageID); err != nil {
+			fmt.Printf("Error deleting cover image %s: %v\n", event.CoverImageID, err)
+		}
+	}
+
+	event.CoverImageID = ""
+	event.CoverPhotoID = nil
+	if err := s.eventRepo.Update(event); err != nil {
+		return nil, err
+	}
+
+	response := s.BuildEventResponse(event)
+	return &response, nil
+}
+
+// IssueGuestToken etkinlik için yeni bir anonim misafir kimliği ve token üretir
+func (s *EventService) IssueGuestToken(eventID uint) (*models.GuestTokenResponse, error) {
+	guestID := uuid.New().String()
+
+	token, err := jwtPkg.GenerateGuestToken(eventID, guestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate guest token: %w", err)
+	}
+
+	return &models.GuestTokenResponse{
+		GuestID:    guestID,
+		GuestToken: token,
+	}, nil
+}
+
+// ResolveGuestID misafir tokenini doğrular ve misafir kimliğini döndürür
+func (s *EventService) ResolveGuestID(eventID uint, token string) (string, error) {
+	if token == "" {
+		return "", errors.New("guest token is required")
+	}
+
+	guestID, err := jwtPkg.ValidateGuestToken(token, eventID)
+	if err != nil {
+		return "", errors.New("invalid guest token")
+	}
+
+	return guestID, nil
 }
 
 // Süresi dolmuş etkinlikleri temizleme metodu
 func (s *EventService) CleanupExpiredEvents() error {
-	// Şu anki tarihten önceki ExpiresAt değerine sahip eventleri bul
-	expiredEvents, err := s.eventRepo.FindExpiredEvents(time.Now())
+	now := time.Now()
+
+	// Süresi yeni dolan etkinlikleri salt okunur olarak işaretle
+	marked, err := s.eventRepo.MarkExpired(now)
+	if err != nil {
+		return fmt.Errorf("failed to mark expired events: %w", err)
+	}
+	if marked > 0 {
+		fmt.Printf("Marked %d events as expired\n", marked)
+	}
+
+	// Sadece ek süresi de dolan etkinlikler kalıcı olarak silinir
+	expiredEvents, err := s.eventRepo.FindExpiredEvents(now.Add(-s.gracePeriod))
 	if err != nil {
 		return fmt.Errorf("failed to find expired events: %w", err)
 	}
@@ -388,34 +967,25 @@ func (s *EventService) CleanupExpiredEvents() error {
 
 	// Her etkinlik için silme işlemini gerçekleştir
 	for _, event := range expiredEvents {
-		// Önce ilişkili fotoğrafları sil
-		photos, err := s.photoService.photoRepo.GetByEventID(event.ID)
+		// Önce ilişkili fotoğrafları kalıcı olarak sil
+		photoCount, err := s.photoService.PurgeEventContent(&event)
 		if err != nil {
-			fmt.Printf("Error retrieving photos for event %d: %v\n", event.ID, err)
-			continue
-		}
-
-		// Her bir fotoğrafı sil
-		for _, photo := range photos {
-			// Cloudflare Images'dan sil
-			if err := s.photoService.ImgStorage.Delete(photo.ImageID); err != nil {
-				fmt.Printf("Error deleting photo %s from Cloudflare Images: %v\n", photo.ImageID, err)
-			}
-		}
-
-		// Veritabanından tüm fotoğrafları sil
-		if err := s.photoService.photoRepo.DeleteByEventID(event.ID); err != nil {
 			fmt.Printf("Error deleting photos for event %d: %v\n", event.ID, err)
+			continue
 		}
 
 		// Etkinliği sil
-		if err := s.eventRepo.Delete(event.ID); err != nil {
+		if err := s.eventRepo.HardDelete(event.ID); err != nil {
 			fmt.Printf("Error deleting event %d: %v\n", event.ID, err)
 			continue
 		}
 
 		fmt.Printf("Successfully deleted expired event %d (%s) with %d photos\n",
-			event.ID, event.Title, len(photos))
+			event.ID, event.Title, photoCount)
+
+		if err := s.purgeLogRepo.Create(newEventPurgeLog(&event, photoCount, models.PurgeReasonExpired)); err != nil {
+			fmt.Printf("Error recording purge log for event %d: %v\n", event.ID, err)
+		}
 
 		// Event sahibinin event limitini geri ver
 		user, err := s.userRepo.GetByID(event.UserID)
@@ -430,19 +1000,129 @@ func (s *EventService) CleanupExpiredEvents() error {
 	return nil
 }
 
-// GetEventQRCode, belirtilen etkinlik için QR kod PNG formatında döndürür
-func (s *EventService) GetEventQRCode(eventID uint, size int) ([]byte, error) {
+// newEventPurgeLog kalıcı olarak silinen etkinlik için denetim kaydı oluşturur
+func newEventPurgeLog(event *models.Event, photoCount int, reason string) *models.EventPurgeLog {
+	return &models.EventPurgeLog{
+		EventID:    event.ID,
+		UserID:     event.UserID,
+		Title:      event.Title,
+		URL:        event.URL,
+		PhotoCount: photoCount,
+		Reason:     reason,
+		ExpiresAt:  event.ExpiresAt,
+		PurgedAt:   time.Now(),
+	}
+}
+
+// SendExpiryWarnings süresi yaklaşan etkinliklerin sahiplerine uyarı e-postası gönderir.
+// Her eşik için bir kez gönderilir, etkinlik o eşiğe girmeden önce oluşturulduysa uyarı atlanır.
+func (s *EventService) SendExpiryWarnings() error {
+	if len(s.warningDays) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	events, err := s.eventRepo.FindExpiringBetween(now, now.AddDate(0, 0, s.warningDays[0]))
+	if err != nil {
+		return fmt.Errorf("failed to find expiring events: %w", err)
+	}
+
+	for _, event := range events {
+		threshold := s.expiryWarningThreshold(&event, now)
+		if threshold == 0 {
+			continue
+		}
+
+		user, err := s.userRepo.GetByID(event.UserID)
+		if err != nil {
+			continue
+		}
+
+		daysLeft := int(event.ExpiresAt.Sub(now).Hours()/24) + 1
+		if err := s.emailService.SendEventExpiringEmail(user.Email, user.FullName, event.Title, event.URL,
+			daysLeft, event.ExpiresAt, event.ExpiresAt.Add(s.gracePeriod)); err != nil {
+			fmt.Printf("Error sending expiry warning for event %d: %v\n", event.ID, err)
+			continue
+		}
+
+		if err := s.eventRepo.SetExpiryWarningDays(event.ID, threshold); err != nil {
+			fmt.Printf("Error saving expiry warning for event %d: %v\n", event.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// expiryWarningThreshold etkinlik için gönderilmesi gereken uyarının gün eşiğini döndürür, gerek yoksa 0
+func (s *EventService) expiryWarningThreshold(event *models.Event, now time.Time) int {
+	remaining := event.ExpiresAt.Sub(now)
+
+	// Girilen en küçük eşik gönderilir, daha önce aynı veya daha küçük eşik gönderildiyse tekrar gönderilmez
+	threshold := 0
+	for _, days := range s.warningDays {
+		window := time.Duration(days) * 24 * time.Hour
+		if remaining > window {
+			break
+		}
+		if event.ExpiresAt.Sub(event.CreatedAt) <= window {
+			continue
+		}
+		threshold = days
+	}
+
+	if threshold == 0 || (event.ExpiryWarningDays != 0 && event.ExpiryWarningDays <= threshold) {
+		return 0
+	}
+	return threshold
+}
+
+// GetEventQRCode, belirtilen etkinlik için QR kodu istenen format ve görünümde döndürür.
+// withLogo true ise etkinliğin kapak görseli kodun ortasına logo olarak yerleştirilir.
+func (s *EventService) GetEventQRCode(eventID uint, format string, opts qrcode.Options, withLogo bool) ([]byte, error) {
 	// Etkinliği getir
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
 		return nil, fmt.Errorf("event not found: %w", err)
 	}
 
+	if opts, err = s.qrOptions(event, opts, withLogo); err != nil {
+		return nil, err
+	}
+
 	// QR kodu oluştur
-	qrCode, err := s.qrService.GenerateQRCode(event.URL, size)
+	qrCode, err := s.qrService.Generate(event.URL, format, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate QR code: %w", err)
 	}
 
 	return qrCode, nil
 }
+
+// qrOptions QR kod ayarlarını doğrular ve istenirse etkinliğin kapak görselini logo olarak ekler
+func (s *EventService) qrOptions(event *models.Event, opts qrcode.Options, withLogo bool) (qrcode.Options, error) {
+	fg, bg := color.RGBAModel.Convert(opts.Foreground), color.RGBAModel.Convert(opts.Background)
+	if fg == bg {
+		return opts, errors.New("foreground and background colors must differ")
+	}
+
+	if !withLogo {
+		return opts, nil
+	}
+
+	if event.CoverImageID == "" {
+		return opts, errors.New("event has no cover image to use as logo")
+	}
+
+	data, err := s.photoService.ImgStorage.Download(event.CoverImageID)
+	if err != nil {
+		return opts, fmt.Errorf("failed to load logo: %w", err)
+	}
+
+	logo, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return opts, fmt.Errorf("failed to decode logo: %w", err)
+	}
+
+	opts.Logo = logo
+	return opts, nil
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+)
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestUploadStatus(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(30 * 24 * time.Hour)
+
+	tests := []struct {
+		name  string
+		event models.Event
+		want  string
+	}{
+		{
+			name:  "open without window",
+			event: models.Event{AllowGuestUploads: true, ExpiresAt: expiresAt},
+			want:  models.UploadStatusOpen,
+		},
+		{
+			name: "open inside window",
+			event: models.Event{
+				AllowGuestUploads: true,
+				ExpiresAt:         expiresAt,
+				UploadOpensAt:     timePtr(now.Add(-time.Hour)),
+				UploadClosesAt:    timePtr(now.Add(time.Hour)),
+			},
+			want: models.UploadStatusOpen,
+		},
+		{
+			name: "opens exactly now",
+			event: models.Event{
+				AllowGuestUploads: true,
+				ExpiresAt:         expiresAt,
+				UploadOpensAt:     timePtr(now),
+			},
+			want: models.UploadStatusOpen,
+		},
+		{
+			name: "scheduled",
+			event: models.Event{
+				AllowGuestUploads: true,
+				ExpiresAt:         expiresAt,
+				UploadOpensAt:     timePtr(now.Add(time.Minute)),
+			},
+			want: models.UploadStatusScheduled,
+		},
+		{
+			name: "closes exactly now",
+			event: models.Event{
+				AllowGuestUploads: true,
+				ExpiresAt:         expiresAt,
+				UploadClosesAt:    timePtr(now),
+			},
+			want: models.UploadStatusClosed,
+		},
+		{
+			name:  "guest uploads disabled",
+			event: models.Event{AllowGuestUploads: false, ExpiresAt: expiresAt},
+			want:  models.UploadStatusDisabled,
+		},
+		{
+			name:  "expired by date",
+			event: models.Event{AllowGuestUploads: true, ExpiresAt: now.Add(-time.Second)},
+			want:  models.UploadStatusExpired,
+		},
+		{
+			name:  "marked expired",
+			event: models.Event{AllowGuestUploads: true, ExpiresAt: expiresAt, IsExpired: true},
+			want:  models.UploadStatusExpired,
+		},
+		{
+			name:  "permanent ignores past expiry",
+			event: models.Event{AllowGuestUploads: true, ExpiresAt: now.Add(-time.Second), IsPermanent: true},
+			want:  models.UploadStatusOpen,
+		},
+		{
+			name:  "expired takes precedence over disabled",
+			event: models.Event{AllowGuestUploads: false, ExpiresAt: now.Add(-time.Second)},
+			want:  models.UploadStatusExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UploadStatus(&tt.event, now); got != tt.want {
+				t.Errorf("UploadStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateUploadWindow(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(7 * 24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		opensAt   *time.Time
+		closesAt  *time.Time
+		expiresAt *time.Time
+		wantErr   string
+	}{
+		{"no window", nil, nil, &expiresAt, ""},
+		{"valid window", timePtr(now), timePtr(now.Add(time.Hour)), &expiresAt, ""},
+		{"only opens", timePtr(now), nil, &expiresAt, ""},
+		{"only closes", nil, timePtr(now.Add(time.Hour)), &expiresAt, ""},
+		{"closes at expiry", nil, timePtr(expiresAt), &expiresAt, ""},
+		{"closes before opens", timePtr(now.Add(time.Hour)), timePtr(now), &expiresAt, "upload window must close after it opens"},
+		{"closes when opens", timePtr(now), timePtr(now), &expiresAt, "upload window must close after it opens"},
+		{"opens at expiry", timePtr(expiresAt), nil, &expiresAt, "upload window must open before the event expires"},
+		{"closes after expiry", nil, timePtr(expiresAt.Add(time.Second)), &expiresAt, "upload window must close before the event expires"},
+		{"permanent event has no expiry bound", nil, timePtr(expiresAt.AddDate(1, 0, 0)), nil, ""},
+		{"permanent event still checks order", timePtr(now.Add(time.Hour)), timePtr(now), nil, "upload window must close after it opens"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUploadWindow(tt.opensAt, tt.closesAt, tt.expiresAt)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateUploadWindow() returned error %q, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("validateUploadWindow() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDurationChangeExpiry(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	createdAt := now.AddDate(0, 0, -3)
+
+	tests := []struct {
+		name     string
+		event    models.Event
+		duration models.DurationType
+		want     time.Time
+		wantErr  string
+	}{
+		{
+			name:     "shortens from creation",
+			event:    models.Event{CreatedAt: createdAt, ExpiresAt: createdAt.AddDate(0, 0, 30)},
+			duration: models.Duration7Days,
+			want:     createdAt.AddDate(0, 0, 7),
+		},
+		{
+			name:     "same duration keeps expiry",
+			event:    models.Event{CreatedAt: createdAt, ExpiresAt: createdAt.AddDate(0, 0, 14)},
+			duration: models.Duration14Days,
+			want:     createdAt.AddDate(0, 0, 14),
+		},
+		{
+			name:     "cannot extend past current expiry",
+			event:    models.Event{CreatedAt: createdAt, ExpiresAt: createdAt.AddDate(0, 0, 7)},
+			duration: models.Duration30Days,
+			wantErr:  "duration cannot extend the event, purchase an extension instead",
+		},
+		{
+			name:     "elapsed duration",
+			event:    models.Event{CreatedAt: now.AddDate(0, 0, -10), ExpiresAt: now.AddDate(0, 0, 20)},
+			duration: models.Duration7Days,
+			wantErr:  "duration has already elapsed since the event was created",
+		},
+		{
+			name:     "expired by date",
+			event:    models.Event{CreatedAt: now.AddDate(0, 0, -10), ExpiresAt: now.Add(-time.Second)},
+			duration: models.Duration30Days,
+			wantErr:  "duration of an expired event cannot be changed",
+		},
+		{
+			name:     "marked expired",
+			event:    models.Event{CreatedAt: createdAt, ExpiresAt: createdAt.AddDate(0, 0, 30), IsExpired: true},
+			duration: models.Duration7Days,
+			wantErr:  "duration of an expired event cannot be changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := durationChangeExpiry(&tt.event, tt.duration, now)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("durationChangeExpiry() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("durationChangeExpiry() returned error %q, want nil", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("durationChangeExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpiryWarningThreshold(t *testing.T) {
+	s := &EventService{warningDays: []int{7, 1}}
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name        string
+		remaining   time.Duration
+		lifetime    time.Duration
+		warningSent int
+		want        int
+	}{
+		{"outside all windows", 10 * day, 30 * day, 0, 0},
+		{"enters first window", 6 * day, 30 * day, 0, 7},
+		{"exactly at first window", 7 * day, 30 * day, 0, 7},
+		{"first window already sent", 6 * day, 30 * day, 7, 0},
+		{"enters last window after first", 12 * time.Hour, 30 * day, 7, 1},
+		{"last window already sent", 12 * time.Hour, 30 * day, 1, 0},
+		{"skipped windows send smallest", 12 * time.Hour, 30 * day, 0, 1},
+		{"created inside first window", 12 * time.Hour, 3 * day, 0, 1},
+		{"created inside last window", 12 * time.Hour, 20 * time.Hour, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expiresAt := now.Add(tt.remaining)
+			event := &models.Event{
+				ExpiresAt:         expiresAt,
+				CreatedAt:         expiresAt.Add(-tt.lifetime),
+				ExpiryWarningDays: tt.warningSent,
+			}
+			if got := s.expiryWarningThreshold(event, now); got != tt.want {
+				t.Errorf("expiryWarningThreshold() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/pkg/bcrypt"
+)
+
+// applyEventTemplate istekte boş bırakılan alanları şablondaki değerlerle doldurur.
+// Bool ayarlar sadece istekte gönderilmediyse şablondan alınır, açıkça kapatılan ayar kapalı kalır.
+func applyEventTemplate(req *models.EventRequest, template *models.EventTemplate) {
+	if req.Description == "" {
+		req.Description = template.Description
+	}
+	if req.Location == "" {
+		req.Location = template.Location
+	}
+	if req.AccentColor == "" {
+		req.AccentColor = template.AccentColor
+	}
+	if req.WelcomeMessage == "" {
+		req.WelcomeMessage = template.WelcomeMessage
+	}
+	if req.ThankYouMessage == "" {
+		req.ThankYouMessage = template.ThankYouMessage
+	}
+	if req.MaxPhotos == 0 {
+		req.MaxPhotos = template.MaxPhotos
+	}
+	if req.MaxPhotosPerGuest == 0 {
+		req.MaxPhotosPerGuest = template.MaxPhotosPerGuest
+	}
+	if req.Duration == "" {
+		req.Duration = template.Duration
+	}
+
+	fillBool(&req.IsPublic, template.IsPublic)
+	fillBool(&req.HasPassword, template.HasPassword)
+	fillBool(&req.AllowGuestUploads, template.AllowGuestUploads)
+	fillBool(&req.CommentsDisabled, template.CommentsDisabled)
+	fillBool(&req.GuestbookDisabled, template.GuestbookDisabled)
+	fillBool(&req.RequireApproval, template.RequireApproval)
+}
+
+// fillBool istekte gönderilmeyen bool ayarı verilen değerle doldurur
+func fillBool(field **bool, value bool) {
+	if *field == nil {
+		*field = &value
+	}
+}
+
+// boolOrDefault istekte gönderilmeyen bool ayar için varsayılan değeri döndürür
+func boolOrDefault(value *bool, fallback bool) bool {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
+
+func (s *EventService) getUserTemplate(templateID uint, userID uint) (*models.EventTemplate, error) {
+	template, err := s.templateRepo.GetByID(templateID)
+	if err != nil || template.UserID != userID {
+		return nil, errors.New("template not found")
+	}
+	return template, nil
+}
+
+func (s *EventService) GetUserTemplates(userID uint) ([]models.EventTemplate, error) {
+	return s.templateRepo.GetByUserID(userID)
+}
+
+func (s *EventService) CreateTemplate(userID uint, req models.EventTemplateRequest) (*models.EventTemplate, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("template name is required")
+	}
+
+	var hashedPassword string
+	if req.HasPassword {
+		if req.Password == "" {
+			return nil, errors.New("password is required for password protected templates")
+		}
+		var err error
+		hashedPassword, err = bcrypt.HashPassword(req.Password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash password: %w", err)
+		}
+	}
+
+	template := &models.EventTemplate{
+		UserID:            userID,
+		Name:              name,
+		Description:       req.Description,
+		Location:          req.Location,
+		IsPublic:          req.IsPublic,
+		HasPassword:       req.HasPassword,
+		Password:          hashedPassword,
+		AllowGuestUploads: req.AllowGuestUploads,
+		CommentsDisabled:  req.CommentsDisabled,
+		GuestbookDisabled: req.GuestbookDisabled,
+		RequireApproval:   req.RequireApproval,
+		AccentColor:       req.AccentColor,
+		WelcomeMessage:    strings.TrimSpace(req.WelcomeMessage),
+		ThankYouMessage:   strings.TrimSpace(req.ThankYouMessage),
+		MaxPhotos:         req.MaxPhotos,
+		MaxPhotosPerGuest: req.MaxPhotosPerGuest,
+		Duration:          req.Duration,
+	}
+
+	if err := s.templateRepo.Create(template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// SaveEventAsTemplate mevcut etkinliğin ayarlarından yeni bir şablon oluşturur
+func (s *EventService) SaveEventAsTemplate(eventID uint, userID uint, name string) (*models.EventTemplate, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	if event.UserID != userID {
+		return nil, errors.New("unauthorized")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("template name is required")
+	}
+
+	template := &models.EventTemplate{
+		UserID:            userID,
+		Name:              name,
+		Description:       event.Description,
+		Location:          event.Location,
+		IsPublic:          event.IsPublic,
+		HasPassword:       event.HasPassword,
+		Password:          event.Password,
+		AllowGuestUploads: event.AllowGuestUploads,
+		CommentsDisabled:  event.CommentsDisabled,
+		GuestbookDisabled: event.GuestbookDisabled,
+		RequireApproval:   event.RequireApproval,
+		AccentColor:       event.AccentColor,
+		WelcomeMessage:    event.WelcomeMessage,
+		ThankYouMessage:   event.ThankYouMessage,
+		MaxPhotos:         event.MaxPhotos,
+		MaxPhotosPerGuest: event.MaxPhotosPerGuest,
+		Duration:          event.Duration,
+	}
+
+	if err := s.templateRepo.Create(template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (s *EventService) DeleteTemplate(templateID uint, userID uint) error {
+	template, err := s.getUserTemplate(templateID, userID)
+	if err != nil {
+		return err
+	}
+	return s.templateRepo.Delete(template.ID)
+}
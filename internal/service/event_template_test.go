@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestApplyEventTemplateBools(t *testing.T) {
+	template := &models.EventTemplate{
+		IsPublic:          true,
+		AllowGuestUploads: true,
+		RequireApproval:   true,
+	}
+
+	req := models.EventRequest{
+		IsPublic:         boolPtr(false),
+		CommentsDisabled: boolPtr(true),
+	}
+	applyEventTemplate(&req, template)
+
+	tests := []struct {
+		name  string
+		field *bool
+		want  bool
+	}{
+		{"explicit false overrides template", req.IsPublic, false},
+		{"explicit true kept", req.CommentsDisabled, true},
+		{"omitted taken from template", req.AllowGuestUploads, true},
+		{"omitted true in template", req.RequireApproval, true},
+		{"omitted false in template", req.GuestbookDisabled, false},
+		{"omitted password flag", req.HasPassword, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.field == nil {
+				t.Fatal("field not filled from template")
+			}
+			if *tt.field != tt.want {
+				t.Errorf("got %v, want %v", *tt.field, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoolOrDefault(t *testing.T) {
+	if !boolOrDefault(nil, true) {
+		t.Error("boolOrDefault(nil, true) = false, want true")
+	}
+	if boolOrDefault(boolPtr(false), true) {
+		t.Error("boolOrDefault(false, true) = true, want false")
+	}
+	if !boolOrDefault(boolPtr(true), false) {
+		t.Error("boolOrDefault(true, false) = false, want true")
+	}
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/repository"
+	"github.com/sefazor/ourphotos-backend/pkg/email"
+)
+
+// EventTransferExpiry devir bağlantısının geçerlilik süresi
+const EventTransferExpiry = 7 * 24 * time.Hour
+
+type EventTransferService struct {
+	transferRepo *repository.EventTransferRepository
+	eventRepo    *repository.EventRepository
+	userRepo     *repository.UserRepository
+	emailService *email.EmailService
+}
+
+func NewEventTransferService(
+	transferRepo *repository.EventTransferRepository,
+	eventRepo *repository.EventRepository,
+	userRepo *repository.UserRepository,
+	emailService *email.EmailService,
+) *EventTransferService {
+	return &EventTransferService{
+		transferRepo: transferRepo,
+		eventRepo:    eventRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+	}
+}
+
+func (s *EventTransferService) getOwnedEvent(eventID uint, userID uint) (*models.Event, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+	if event.UserID != userID {
+		return nil, errors.New("unauthorized")
+	}
+	return event, nil
+}
+
+// GetPendingTransfer etkinliğin bekleyen devrini etkinlik sahibine döndürür
+func (s *EventTransferService) GetPendingTransfer(eventID uint, userID uint) (*models.EventTransfer, error) {
+	if _, err := s.getOwnedEvent(eventID, userID); err != nil {
+		return nil, err
+	}
+
+	transfer, err := s.transferRepo.GetPendingByEventID(eventID)
+	if err != nil || !time.Now().Before(transfer.ExpiresAt) {
+		return nil, errors.New("transfer not found")
+	}
+	return transfer, nil
+}
+
+// CreateTransfer etkinliğin verilen e-posta adresine devrini başlatır ve alıcıya kabul bağlantısını gönderir.
+// Etkinliğin önceki bekleyen devirleri iptal edilir.
+func (s *EventTransferService) CreateTransfer(eventID uint, userID uint, req models.CreateEventTransferRequest) (*models.EventTransfer, error) {
+	event, err := s.getOwnedEvent(eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	toEmail := strings.TrimSpace(req.Email)
+	if strings.EqualFold(toEmail, owner.Email) {
+		return nil, errors.New("cannot transfer an event to yourself")
+	}
+
+	now := time.Now()
+	if err := s.transferRepo.CancelPending(event.ID, now); err != nil {
+		return nil, err
+	}
+
+	transfer := &models.EventTransfer{
+		EventID:    event.ID,
+		FromUserID: userID,
+		ToEmail:    toEmail,
+		Token:      uuid.New().String(),
+		Status:     models.TransferStatusPending,
+		ExpiresAt:  now.Add(EventTransferExpiry),
+	}
+	if err := s.transferRepo.Create(transfer); err != nil {
+		return nil, err
+	}
+
+	if err := s.emailService.SendEventTransferEmail(toEmail, owner.FullName, event.Title, transfer.Token, transfer.ExpiresAt); err != nil {
+		// Alıcıya ulaşmayan devir beklemede kalmasın
+		transfer.Status = models.TransferStatusCancelled
+		transfer.RespondedAt = &now
+		if err := s.transferRepo.Update(transfer); err != nil {
+			fmt.Printf("Error cancelling undelivered transfer %d: %v\n", transfer.ID, err)
+		}
+		return nil, errors.New("failed to send transfer email")
+	}
+
+	return transfer, nil
+}
+
+// CancelTransfer etkinliğin bekleyen devrini iptal eder
+func (s *EventTransferService) CancelTransfer(eventID uint, userID uint) error {
+	if _, err := s.GetPendingTransfer(eventID, userID); err != nil {
+		return err
+	}
+	return s.transferRepo.CancelPending(eventID, time.Now())
+}
+
+// getRecipientTransfer token'a ait bekleyen devri, isteği yapan kullanıcının alıcı olduğunu doğrulayarak döndürür
+func (s *EventTransferService) getRecipientTransfer(token string, userID uint) (*models.EventTransfer, *models.User, error) {
+	transfer, err := s.transferRepo.GetByToken(token)
+	if err != nil {
+		return nil, nil, errors.New("transfer not found")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !strings.EqualFold(user.Email, transfer.ToEmail) {
+		return nil, nil, errors.New("transfer was sent to a different email")
+	}
+	if transfer.Status != models.TransferStatusPending {
+		return nil, nil, errors.New("transfer is no longer pending")
+	}
+	if !time.Now().Before(transfer.ExpiresAt) {
+		return nil, nil, errors.New("transfer has expired")
+	}
+
+	return transfer, user, nil
+}
+
+// GetTransferPreview alıcıya kabul etmeden önce devredilen etkinliğin bilgilerini döndürür
+func (s *EventTransferService) GetTransferPreview(token string, userID uint) (*models.EventTransferPreviewResponse, error) {
+	transfer, _, err := s.getRecipientTransfer(token, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.eventRepo.GetByID(transfer.EventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	preview := &models.EventTransferPreviewResponse{
+		EventTitle: event.Title,
+		EventURL:   event.URL,
+		PhotoCount: event.PhotoCount,
+		ToEmail:    transfer.ToEmail,
+		Status:     transfer.Status,
+		ExpiresAt:  transfer.ExpiresAt,
+	}
+	if sender, err := s.userRepo.GetByID(transfer.FromUserID); err == nil {
+		preview.FromName = sender.FullName
+	}
+
+	return preview, nil
+}
+
+// AcceptTransfer devri kabul eder ve etkinliği alıcının hesabına taşır.
+// Alıcının etkinlik hakkından bir düşülür, devreden kullanıcıya bir hak iade edilir. Mevcut fotoğrafların
+// harcadığı fotoğraf hakları devredenin hesabında kalır, bundan sonraki yüklemeler yeni sahibin hakkından düşer.
+// Önceki sahibin yüklediği fotoğraflar ona ait olmaya devam eder.
+func (s *EventTransferService) AcceptTransfer(token string, userID uint) (*models.Event, error) {
+	transfer, user, err := s.getRecipientTransfer(token, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsVerified {
+		return nil, errors.New("email address is not verified")
+	}
+	if user.ID == transfer.FromUserID {
+		return nil, errors.New("cannot transfer an event to yourself")
+	}
+
+	if err := s.transferRepo.Accept(transfer, user.ID, time.Now()); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNoEventCredits):
+			return nil, errors.New("no event credits left")
+		case errors.Is(err, repository.ErrEventOwnerChanged):
+			return nil, errors.New("transfer is no longer valid")
+		}
+		return nil, err
+	}
+
+	return s.eventRepo.GetByID(transfer.EventID)
+}
+
+// DeclineTransfer alıcının devri reddetmesini kaydeder
+func (s *EventTransferService) DeclineTransfer(token string, userID uint) error {
+	transfer, _, err := s.getRecipientTransfer(token, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	transfer.Status = models.TransferStatusDeclined
+	transfer.RespondedAt = &now
+	return s.transferRepo.Update(transfer)
+}
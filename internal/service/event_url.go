@@ -0,0 +1,48 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+const (
+	minEventURLLength = 3
+	maxEventURLLength = 50
+)
+
+// eventURLPattern küçük harf, rakam ve tireden oluşan, tire ile başlamayan ve bitmeyen URL'ler
+var eventURLPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// reservedEventURLs uygulama sayfaları ve API yollarıyla çakışabilecek URL'ler
+var reservedEventURLs = map[string]bool{
+	"about": true, "account": true, "admin": true, "api": true, "app": true,
+	"assets": true, "auth": true, "billing": true, "blog": true, "checkout": true,
+	"contact": true, "create": true, "dashboard": true, "detail": true, "e": true,
+	"edit": true, "event": true, "events": true, "gallery": true, "guest-upload": true,
+	"help": true, "home": true, "login": true, "logout": true, "new": true,
+	"ourphotos": true, "packages": true, "payments": true, "photos": true, "pricing": true,
+	"privacy": true, "profile": true, "register": true, "reset-password": true, "search": true,
+	"settings": true, "signup": true, "static": true, "support": true, "terms": true,
+	"trash": true, "upload": true, "url": true, "user": true, "verify-email": true,
+	"www": true,
+}
+
+// normalizeEventURL kullanıcının girdiği URL'yi küçük harfe çevirir ve boşlukları temizler
+func normalizeEventURL(url string) string {
+	return strings.ToLower(strings.TrimSpace(url))
+}
+
+// validateEventURL özel etkinlik URL'sinin uzunluk, karakter seti ve rezerve kelime kurallarına uyduğunu kontrol eder
+func validateEventURL(url string) error {
+	if len(url) < minEventURLLength || len(url) > maxEventURLLength {
+		return errors.New("url must be between 3 and 50 characters")
+	}
+	if !eventURLPattern.MatchString(url) {
+		return errors.New("url may only contain lowercase letters, numbers and single hyphens")
+	}
+	if reservedEventURLs[url] {
+		return errors.New("url is reserved")
+	}
+	return nil
+}
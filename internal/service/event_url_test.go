@@ -0,0 +1,63 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeEventURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"my-wedding", "my-wedding"},
+		{"  My-Wedding  ", "my-wedding"},
+		{"PARTY2024", "party2024"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeEventURL(tt.in); got != tt.want {
+			t.Errorf("normalizeEventURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValidateEventURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr string
+	}{
+		{"valid", "ayse-ve-mehmet", ""},
+		{"digits", "party2024", ""},
+		{"min length", "abc", ""},
+		{"max length", strings.Repeat("a", maxEventURLLength), ""},
+		{"too short", "ab", "url must be between 3 and 50 characters"},
+		{"too long", strings.Repeat("a", maxEventURLLength+1), "url must be between 3 and 50 characters"},
+		{"uppercase", "My-Wedding", "url may only contain lowercase letters, numbers and single hyphens"},
+		{"leading hyphen", "-wedding", "url may only contain lowercase letters, numbers and single hyphens"},
+		{"trailing hyphen", "wedding-", "url may only contain lowercase letters, numbers and single hyphens"},
+		{"double hyphen", "my--wedding", "url may only contain lowercase letters, numbers and single hyphens"},
+		{"underscore", "my_wedding", "url may only contain lowercase letters, numbers and single hyphens"},
+		{"space", "my wedding", "url may only contain lowercase letters, numbers and single hyphens"},
+		{"non ascii", "düğün", "url may only contain lowercase letters, numbers and single hyphens"},
+		{"reserved", "admin", "url is reserved"},
+		{"reserved with hyphen", "guest-upload", "url is reserved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEventURL(tt.url)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateEventURL(%q) returned error %q, want nil", tt.url, err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("validateEventURL(%q) = %v, want %q", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
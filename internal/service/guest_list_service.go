@@ -0,0 +1,410 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/repository"
+	"github.com/sefazor/ourphotos-backend/pkg/bcrypt"
+	"github.com/sefazor/ourphotos-backend/pkg/email"
+)
+
+// Tek seferde içe aktarılabilecek en fazla misafir sayısı
+const maxGuestImport = 1000
+
+// Davet ve duyuru e-postaları servisin hız sınırına takılmamak için arka planda gruplar halinde gönderilir
+const (
+	guestEmailBatchSize  = 10
+	guestEmailBatchPause = 5 * time.Second
+	guestEmailQueueStale = time.Hour // Bu süreden uzun kuyrukta kalan e-posta tekrar gönderilebilir
+)
+
+type GuestListService struct {
+	guestRepo      *repository.EventGuestRepository
+	eventRepo      *repository.EventRepository
+	userRepo       *repository.UserRepository
+	accessCodeRepo *repository.AccessCodeRepository
+	emailService   *email.EmailService
+}
+
+func NewGuestListService(
+	guestRepo *repository.EventGuestRepository,
+	eventRepo *repository.EventRepository,
+	userRepo *repository.UserRepository,
+	accessCodeRepo *repository.AccessCodeRepository,
+	emailService *email.EmailService,
+) *GuestListService {
+	return &GuestListService{
+		guestRepo:      guestRepo,
+		eventRepo:      eventRepo,
+		userRepo:       userRepo,
+		accessCodeRepo: accessCodeRepo,
+		emailService:   emailService,
+	}
+}
+
+// ParseGuestCSV "email,name" sütunlarından oluşan CSV dosyasını okur. İlk satır başlık ise atlanır.
+func ParseGuestCSV(r io.Reader) ([]models.GuestListEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.New("invalid csv file")
+	}
+
+	if len(records) > 0 && len(records[0]) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), "email") {
+		records = records[1:]
+	}
+
+	entries := make([]models.GuestListEntry, 0, len(records))
+	for _, record := range records {
+		if len(record) == 0 || strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		entry := models.GuestListEntry{Email: record[0]}
+		if len(record) > 1 {
+			entry.Name = record[1]
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (s *GuestListService) getOwnedEvent(eventID uint, userID uint) (*models.Event, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+	if event.UserID != userID {
+		return nil, errors.New("unauthorized")
+	}
+	return event, nil
+}
+
+func (s *GuestListService) GetGuests(eventID uint, userID uint) ([]models.EventGuest, error) {
+	if _, err := s.getOwnedEvent(eventID, userID); err != nil {
+		return nil, err
+	}
+	return s.guestRepo.GetByEventID(eventID)
+}
+
+// ImportGuests e-posta adreslerini davet listesine ekler. Geçersiz adresler raporlanır,
+// listede zaten bulunan adresler atlanır.
+func (s *GuestListService) ImportGuests(eventID uint, userID uint, entries []models.GuestListEntry) (*models.ImportGuestsResponse, error) {
+	if _, err := s.getOwnedEvent(eventID, userID); err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.New("guest list is empty")
+	}
+	if len(entries) > maxGuestImport {
+		return nil, fmt.Errorf("a maximum of %d guests can be imported at once", maxGuestImport)
+	}
+
+	response := &models.ImportGuestsResponse{Invalid: []string{}}
+	seen := make(map[string]bool)
+	guests := make([]models.EventGuest, 0, len(entries))
+
+	for _, entry := range entries {
+		address, err := mail.ParseAddress(strings.TrimSpace(entry.Email))
+		if err != nil || len(address.Address) > 255 {
+			response.Invalid = append(response.Invalid, entry.Email)
+			continue
+		}
+
+		emailAddress := strings.ToLower(address.Address)
+		if seen[emailAddress] {
+			response.Skipped++
+			continue
+		}
+		seen[emailAddress] = true
+
+		name := strings.TrimSpace(entry.Name)
+		if name == "" {
+			name = address.Name
+		}
+		if len([]rune(name)) > 100 {
+			name = string([]rune(name)[:100])
+		}
+
+		guests = append(guests, models.EventGuest{
+			EventID:     eventID,
+			Email:       emailAddress,
+			Name:        name,
+			InviteToken: uuid.New().String(),
+			Status:      models.GuestStatusPending,
+		})
+	}
+
+	imported, err := s.guestRepo.CreateMany(guests)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Imported = imported
+	response.Skipped += len(guests) - imported
+	return response, nil
+}
+
+// DeleteGuest misafiri listeden çıkarır ve misafire verilen erişim kodunu iptal eder
+func (s *GuestListService) DeleteGuest(eventID uint, guestID uint, userID uint) error {
+	if _, err := s.getOwnedEvent(eventID, userID); err != nil {
+		return err
+	}
+
+	guest, err := s.guestRepo.GetByID(guestID)
+	if err != nil || guest.EventID != eventID {
+		return errors.New("guest not found")
+	}
+
+	if guest.AccessCodeID != nil {
+		if err := s.accessCodeRepo.Delete(*guest.AccessCodeID); err != nil {
+			return err
+		}
+	}
+
+	return s.guestRepo.Delete(guest.ID)
+}
+
+// guestAccessCode misafirin erişim kodunu davet tokeninden türetir. Kodlar hashlenmiş saklandığından
+// yeniden davette aynı kodun tekrar gönderilebilmesi için kod rastgele değil tokenden üretilir.
+func guestAccessCode(inviteToken string) string {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("JWT_SECRET")))
+	mac.Write([]byte("guest-access-code:" + inviteToken))
+	return encodeAccessCode(mac.Sum(nil))
+}
+
+// issueGuestAccessCode parola korumalı etkinlikte misafire özel erişim kodunu döndürür. Misafirin
+// geçerli bir kodu varsa aynı kod tekrar kullanılır, böylece ilk davetteki kod çalışmaya devam eder.
+func (s *GuestListService) issueGuestAccessCode(event *models.Event, guest *models.EventGuest) (string, error) {
+	if !event.HasPassword {
+		return "", nil
+	}
+
+	plainCode := guestAccessCode(guest.InviteToken)
+
+	if guest.AccessCodeID != nil {
+		code, err := s.accessCodeRepo.GetByID(*guest.AccessCodeID)
+		if err == nil && code.EventID == event.ID && !isAccessCodeExpired(code, time.Now()) &&
+			code.CodeLookup == accessCodeLookup(plainCode) {
+			return plainCode, nil
+		}
+
+		// Süresi dolmuş veya tokenden türetilmemiş eski kod yenisiyle değiştirilir
+		if err == nil {
+			if err := s.accessCodeRepo.Delete(code.ID); err != nil {
+				return "", err
+			}
+		}
+		guest.AccessCodeID = nil
+	}
+
+	if err := checkAccessCodeLimit(s.accessCodeRepo, event.ID); err != nil {
+		return "", err
+	}
+
+	codeHash, err := bcrypt.HashPassword(plainCode)
+	if err != nil {
+		return "", err
+	}
+
+	label := "Invitation: " + guest.Email
+	if len(label) > 100 {
+		label = label[:100]
+	}
+
+	code := &models.EventAccessCode{
+		EventID:    event.ID,
+		Label:      label,
+		CodeHash:   codeHash,
+		CodeLookup: accessCodeLookup(plainCode),
+		Role:       models.AccessRoleUpload,
+	}
+	if err := s.accessCodeRepo.Create(code); err != nil {
+		return "", err
+	}
+
+	guest.AccessCodeID = &code.ID
+	return plainCode, nil
+}
+
+// SendInvitations seçilen misafirlere, seçim yoksa henüz davet edilmemiş misafirlere davet e-postası
+// gönderimini başlatır. Gönderim arka planda yapılır, misafirlerin durumu gönderildikçe güncellenir.
+func (s *GuestListService) SendInvitations(eventID uint, userID uint, guestIDs []uint) (*models.GuestEmailQueueResult, error) {
+	event, err := s.getOwnedEvent(eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsEventExpired(event, time.Now()) {
+		return nil, errors.New("event has expired")
+	}
+
+	var guests []models.EventGuest
+	if len(guestIDs) > 0 {
+		guests, err = s.guestRepo.GetByIDs(eventID, guestIDs)
+	} else {
+		guests, err = s.guestRepo.GetUninvited(eventID, time.Now().Add(-guestEmailQueueStale))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(guests) == 0 {
+		return &models.GuestEmailQueueResult{}, nil
+	}
+
+	// Kuyruğa alınan misafirler gönderim bitene kadar tekrar seçilmez
+	ids := make([]uint, len(guests))
+	for i := range guests {
+		ids[i] = guests[i].ID
+	}
+	if err := s.guestRepo.MarkQueued(ids); err != nil {
+		return nil, err
+	}
+
+	hostName := ""
+	if owner, err := s.userRepo.GetByID(event.UserID); err == nil {
+		hostName = owner.FullName
+	}
+
+	go s.deliverInvitations(event, hostName, guests)
+
+	return &models.GuestEmailQueueResult{Queued: len(guests)}, nil
+}
+
+// deliverInvitations davet e-postalarını gruplar halinde gönderir ve her misafirin sonucunu kaydeder
+func (s *GuestListService) deliverInvitations(event *models.Event, hostName string, guests []models.EventGuest) {
+	sent, failed := 0, 0
+	for i := range guests {
+		waitForEmailBatch(i)
+
+		guest := &guests[i]
+		accessCode, err := s.issueGuestAccessCode(event, guest)
+		if err == nil {
+			err = s.emailService.SendEventInvitationEmail(guest.Email, guest.Name, hostName, event.Title, event.URL, guest.InviteToken, accessCode)
+		}
+
+		status := models.GuestStatusDelivered
+		var invitedAt *time.Time
+		if err != nil {
+			fmt.Printf("Failed to send invitation to guest %d of event %d: %v\n", guest.ID, event.ID, err)
+			status = models.GuestStatusFailed
+			failed++
+		} else {
+			now := time.Now()
+			invitedAt = &now
+			sent++
+		}
+
+		if err := s.guestRepo.SetInvitationResult(guest, status, invitedAt); err != nil {
+			fmt.Printf("Failed to update invitation status of guest %d: %v\n", guest.ID, err)
+		}
+	}
+
+	fmt.Printf("Invitations for event %d delivered: %d sent, %d failed\n", event.ID, sent, failed)
+}
+
+// waitForEmailBatch her grup gönderildikten sonra e-posta servisinin hız sınırı için bekler
+func waitForEmailBatch(i int) {
+	if i > 0 && i%guestEmailBatchSize == 0 {
+		time.Sleep(guestEmailBatchPause)
+	}
+}
+
+// SendGalleryReady etkinlik sona erdikten sonra listedeki herkese galerinin hazır olduğunu duyurur.
+// Duyuru her misafire bir kez gönderilir. Gönderim arka planda yapılır.
+func (s *GuestListService) SendGalleryReady(eventID uint, userID uint) (*models.GuestEmailQueueResult, error) {
+	event, err := s.getOwnedEvent(eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch UploadStatus(event, now) {
+	case models.UploadStatusClosed, models.UploadStatusExpired:
+	default:
+		return nil, errors.New("event has not ended yet")
+	}
+
+	guests, err := s.guestRepo.QueueGalleryNotifications(eventID, now, now.Add(-guestEmailQueueStale))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(guests) > 0 {
+		go s.deliverGalleryReady(event, guests)
+	}
+
+	return &models.GuestEmailQueueResult{Queued: len(guests)}, nil
+}
+
+// deliverGalleryReady "galeri hazır" duyurularını gruplar halinde gönderir ve her misafirin sonucunu kaydeder
+func (s *GuestListService) deliverGalleryReady(event *models.Event, guests []models.EventGuest) {
+	sent, failed := 0, 0
+	for i := range guests {
+		waitForEmailBatch(i)
+
+		guest := &guests[i]
+		var notifiedAt *time.Time
+		if err := s.emailService.SendGalleryReadyEmail(guest.Email, guest.Name, event.Title, event.URL, guest.InviteToken, event.PhotoCount); err != nil {
+			fmt.Printf("Failed to send gallery ready email to guest %d of event %d: %v\n", guest.ID, event.ID, err)
+			failed++
+		} else {
+			now := time.Now()
+			notifiedAt = &now
+			sent++
+		}
+
+		if err := s.guestRepo.SetGalleryResult(guest.ID, notifiedAt); err != nil {
+			fmt.Printf("Failed to update gallery notification of guest %d: %v\n", guest.ID, err)
+		}
+	}
+
+	fmt.Printf("Gallery ready announcements for event %d delivered: %d sent, %d failed\n", event.ID, sent, failed)
+}
+
+// TrackInvitationOpen davet e-postasındaki takip pikseli yüklendiğinde çağrılır
+func (s *GuestListService) TrackInvitationOpen(inviteToken string) {
+	guest, err := s.guestRepo.GetByInviteToken(inviteToken)
+	if err != nil {
+		return
+	}
+
+	if err := s.guestRepo.MarkOpened(guest.ID, time.Now()); err != nil {
+		fmt.Printf("Failed to track invitation open for guest %d: %v\n", guest.ID, err)
+	}
+}
+
+// TrackInvitationVisit davetteki bağlantı kullanıldığında ziyareti kaydeder ve etkinliğin URL'sini döndürür
+func (s *GuestListService) TrackInvitationVisit(inviteToken string) (string, error) {
+	guest, err := s.guestRepo.GetByInviteToken(inviteToken)
+	if err != nil {
+		return "", errors.New("invitation not found")
+	}
+
+	event, err := s.eventRepo.GetByID(guest.EventID)
+	if err != nil {
+		return "", errors.New("event not found")
+	}
+
+	if err := s.guestRepo.MarkVisited(guest.ID, time.Now()); err != nil {
+		fmt.Printf("Failed to track invitation visit for guest %d: %v\n", guest.ID, err)
+	}
+
+	return event.URL, nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGuestAccessCode(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	code := guestAccessCode("4b0c1f0e-8f4e-4f7a-9a51-3f1c2d6e7a10")
+	if len(code) != accessCodeLength {
+		t.Fatalf("code length = %d, want %d", len(code), accessCodeLength)
+	}
+	for _, r := range code {
+		if !strings.ContainsRune(accessCodeCharset, r) {
+			t.Fatalf("code %q contains %q outside the charset", code, r)
+		}
+	}
+
+	if again := guestAccessCode("4b0c1f0e-8f4e-4f7a-9a51-3f1c2d6e7a10"); again != code {
+		t.Errorf("code is not stable across invitations: %q != %q", again, code)
+	}
+	if other := guestAccessCode("9d2e6b3a-1c4f-4e8d-b7a2-5f0e9c8d1b34"); other == code {
+		t.Errorf("different invitations produced the same code %q", code)
+	}
+
+	t.Setenv("JWT_SECRET", "another-secret")
+	if rotated := guestAccessCode("4b0c1f0e-8f4e-4f7a-9a51-3f1c2d6e7a10"); rotated == code {
+		t.Errorf("code does not depend on the server secret")
+	}
+}
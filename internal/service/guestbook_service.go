@@ -0,0 +1,256 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/repository"
+)
+
+type GuestbookService struct {
+	guestbookRepo *repository.GuestbookRepository
+	photoRepo     *repository.PhotoRepository
+	eventRepo     *repository.EventRepository
+	userRepo      *repository.UserRepository
+}
+
+func NewGuestbookService(
+	guestbookRepo *repository.GuestbookRepository,
+	photoRepo *repository.PhotoRepository,
+	eventRepo *repository.EventRepository,
+	userRepo *repository.UserRepository,
+) *GuestbookService {
+	return &GuestbookService{
+		guestbookRepo: guestbookRepo,
+		photoRepo:     photoRepo,
+		eventRepo:     eventRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// ListPublicEntries etkinliğin yayınlanmış anı defteri girdilerini sayfalı olarak döndürür
+func (s *GuestbookService) ListPublicEntries(event *models.Event, page, limit int) ([]models.GuestbookEntryResponse, int64, error) {
+	entries, total, err := s.guestbookRepo.GetPublicByEventID(event.ID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.buildEntryResponses(entries, false), total, nil
+}
+
+// ListEventEntries etkinlik sahibine gizli ve onay bekleyenler dahil tüm girdileri döndürür
+func (s *GuestbookService) ListEventEntries(eventID uint, userID uint, page, limit int) ([]models.GuestbookEntryResponse, int64, error) {
+	if _, err := s.getOwnedEvent(eventID, userID); err != nil {
+		return nil, 0, err
+	}
+
+	entries, total, err := s.guestbookRepo.GetByEventID(eventID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.buildEntryResponses(entries, true), total, nil
+}
+
+// CreateEntry anı defterine yeni bir girdi ekler. userID 0 ise girdi misafir adına kaydedilir.
+func (s *GuestbookService) CreateEntry(event *models.Event, userID uint, guestID string, req models.CreateGuestbookEntryRequest) (*models.GuestbookEntryResponse, error) {
+	if event.GuestbookDisabled {
+		return nil, errors.New("guestbook is disabled for this event")
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if message == "" {
+		return nil, errors.New("message is required")
+	}
+
+	entry := &models.GuestbookEntry{
+		EventID: event.ID,
+		GuestID: guestID,
+		Message: message,
+	}
+
+	if req.PhotoID != nil {
+		photo, err := s.photoRepo.GetByID(*req.PhotoID)
+		if err != nil || photo.EventID != event.ID || photo.Hidden() || photo.PendingApproval {
+			return nil, errors.New("photo not found")
+		}
+		entry.PhotoID = &photo.ID
+	}
+
+	if userID > 0 {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		entry.UserID = &user.ID
+		entry.GuestName = user.FullName
+	} else {
+		entry.GuestName = strings.TrimSpace(req.GuestName)
+		if entry.GuestName == "" {
+			return nil, errors.New("guest name is required")
+		}
+	}
+
+	// Etkinlik sahibi dışındaki girdiler, fotoğraflarda olduğu gibi onay ayarına tabidir
+	entry.PendingApproval = event.RequireApproval && userID != event.UserID
+
+	if err := s.guestbookRepo.Create(entry); err != nil {
+		return nil, err
+	}
+
+	responses := s.buildEntryResponses([]models.GuestbookEntry{*entry}, true)
+	return &responses[0], nil
+}
+
+// ModerateEntry etkinlik sahibinin bir girdiyi gizlemesini, tekrar göstermesini veya onaylamasını sağlar
+func (s *GuestbookService) ModerateEntry(eventID uint, entryID uint, userID uint, action string) (*models.GuestbookEntryResponse, error) {
+	entry, err := s.getOwnedEntry(eventID, entryID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch action {
+	case models.GuestbookActionHide:
+		entry.IsHidden = true
+	case models.GuestbookActionUnhide:
+		entry.IsHidden = false
+	case models.GuestbookActionApprove:
+		entry.PendingApproval = false
+	default:
+		return nil, errors.New("invalid moderation action")
+	}
+
+	if err := s.guestbookRepo.Update(entry); err != nil {
+		return nil, err
+	}
+
+	responses := s.buildEntryResponses([]models.GuestbookEntry{*entry}, true)
+	return &responses[0], nil
+}
+
+func (s *GuestbookService) DeleteEntry(eventID uint, entryID uint, userID uint) error {
+	entry, err := s.getOwnedEntry(eventID, entryID, userID)
+	if err != nil {
+		return err
+	}
+	return s.guestbookRepo.Delete(entry.ID)
+}
+
+// ExportEntries etkinliğin tüm anı defteri girdilerini CSV olarak döndürür
+func (s *GuestbookService) ExportEntries(eventID uint, userID uint) ([]byte, error) {
+	if _, err := s.getOwnedEvent(eventID, userID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.guestbookRepo.GetAllByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"id", "author_name", "is_guest", "message", "photo_url", "is_hidden", "pending_approval", "created_at"})
+
+	for _, entry := range s.buildEntryResponses(entries, true) {
+		writer.Write([]string{
+			strconv.FormatUint(uint64(entry.ID), 10),
+			csvSafe(entry.AuthorName),
+			strconv.FormatBool(entry.IsGuest),
+			csvSafe(entry.Message),
+			entry.PhotoURL,
+			strconv.FormatBool(entry.IsHidden),
+			strconv.FormatBool(entry.PendingApproval),
+			entry.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// csvSafe misafirin yazdığı değerin tablo programlarında formül olarak çalıştırılmaması için
+// formül başlatan karakterle başlayan hücrelerin başına tek tırnak ekler
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (s *GuestbookService) getOwnedEvent(eventID uint, userID uint) (*models.Event, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+	if event.UserID != userID {
+		return nil, errors.New("unauthorized")
+	}
+	return event, nil
+}
+
+func (s *GuestbookService) getOwnedEntry(eventID uint, entryID uint, userID uint) (*models.GuestbookEntry, error) {
+	if _, err := s.getOwnedEvent(eventID, userID); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.guestbookRepo.GetByID(entryID)
+	if err != nil || entry.EventID != eventID {
+		return nil, errors.New("entry not found")
+	}
+	return entry, nil
+}
+
+// buildEntryResponses girdileri bağlı fotoğrafların URL'leriyle birlikte response modeline dönüştürür.
+// Silinen, gizlenen veya onay bekleyen fotoğrafların bağlantısı gösterilmez.
+func (s *GuestbookService) buildEntryResponses(entries []models.GuestbookEntry, includeModeration bool) []models.GuestbookEntryResponse {
+	var photoIDs []uint
+	for _, entry := range entries {
+		if entry.PhotoID != nil {
+			photoIDs = append(photoIDs, *entry.PhotoID)
+		}
+	}
+
+	photoURLs := make(map[uint]string)
+	if len(photoIDs) > 0 {
+		if photos, err := s.photoRepo.GetByIDs(photoIDs); err == nil {
+			for _, photo := range photos {
+				if !photo.Hidden() && !photo.PendingApproval {
+					photoURLs[photo.ID] = photo.PublicURL
+				}
+			}
+		}
+	}
+
+	responses := make([]models.GuestbookEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		response := models.GuestbookEntryResponse{
+			ID:         entry.ID,
+			UserID:     entry.UserID,
+			AuthorName: entry.GuestName,
+			IsGuest:    entry.UserID == nil,
+			Message:    entry.Message,
+			CreatedAt:  entry.CreatedAt,
+		}
+
+		if entry.PhotoID != nil {
+			if url, ok := photoURLs[*entry.PhotoID]; ok {
+				response.PhotoID = entry.PhotoID
+				response.PhotoURL = url
+			}
+		}
+
+		if includeModeration {
+			response.IsHidden = entry.IsHidden
+			response.PendingApproval = entry.PendingApproval
+		}
+
+		responses = append(responses, response)
+	}
+	return responses
+}
@@ -0,0 +1,27 @@
+package service
+
+import "testing"
+
+func TestCSVSafe(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"Congratulations!", "Congratulations!"},
+		{"=HYPERLINK(\"http://example.com\")", "'=HYPERLINK(\"http://example.com\")"},
+		{"+90 555 000 00 00", "'+90 555 000 00 00"},
+		{"-1+1", "'-1+1"},
+		{"@SUM(A1:A2)", "'@SUM(A1:A2)"},
+		{"\t=1+1", "'\t=1+1"},
+		{"\r=1+1", "'\r=1+1"},
+		{"a=1+1", "a=1+1"},
+		{"Ayşe & Mehmet", "Ayşe & Mehmet"},
+	}
+
+	for _, tt := range tests {
+		if got := csvSafe(tt.in); got != tt.want {
+			t.Errorf("csvSafe(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
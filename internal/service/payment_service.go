@@ -2,8 +2,10 @@ package service
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/sefazor/ourphotos-backend/internal/models"
 	"github.com/sefazor/ourphotos-backend/internal/repository"
@@ -13,22 +15,62 @@ import (
 	"github.com/stripe/stripe-go/v74/product"
 )
 
+// Checkout metadata'sındaki satın alma türleri
+const (
+	purchaseTypeMetadataKey = "purchase_type"
+	purchaseTypeCredits     = "credits"
+	purchaseTypeExtension   = "event_extension"
+)
+
 type PaymentService struct {
 	stripeService *payment.StripeService
 	userRepo      *repository.UserRepository
 	packageRepo   *repository.CreditPackageRepository
 	purchaseRepo  *repository.UserCreditPurchaseRepository
+	eventRepo     *repository.EventRepository
+	extensionRepo *repository.EventExtensionRepository
 }
 
-func NewPaymentService(stripeService *payment.StripeService, userRepo *repository.UserRepository, packageRepo *repository.CreditPackageRepository, purchaseRepo *repository.UserCreditPurchaseRepository) *PaymentService {
+func NewPaymentService(
+	stripeService *payment.StripeService,
+	userRepo *repository.UserRepository,
+	packageRepo *repository.CreditPackageRepository,
+	purchaseRepo *repository.UserCreditPurchaseRepository,
+	eventRepo *repository.EventRepository,
+	extensionRepo *repository.EventExtensionRepository,
+) *PaymentService {
 	return &PaymentService{
 		stripeService: stripeService,
 		userRepo:      userRepo,
 		packageRepo:   packageRepo,
 		purchaseRepo:  purchaseRepo,
+		eventRepo:     eventRepo,
+		extensionRepo: extensionRepo,
 	}
 }
 
+// createOneTimePrice checkout için Stripe'da geçici product ve price oluşturur
+func createOneTimePrice(name string, description string, amount float64) (string, error) {
+	prod, err := product.New(&stripe.ProductParams{
+		Name:        stripe.String(name),
+		Description: stripe.String(description),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	p, err := price.New(&stripe.PriceParams{
+		Product:    stripe.String(prod.ID),
+		UnitAmount: stripe.Int64(int64(amount * 100)), // USD to cents
+		Currency:   stripe.String(string(stripe.CurrencyUSD)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return p.ID, nil
+}
+
 func (s *PaymentService) CreateCheckoutSession(userID uint, packageID uint) (*models.CheckoutSession, error) {
 	// Paketi bul
 	creditPackage, err := s.packageRepo.GetByID(packageID)
@@ -42,25 +84,12 @@ func (s *PaymentService) CreateCheckoutSession(userID uint, packageID uint) (*mo
 		return nil, err
 	}
 
-	// Stripe'da geçici product oluştur
-	productParams := &stripe.ProductParams{
-		Name: stripe.String(creditPackage.Name),
-		Description: stripe.String(fmt.Sprintf("%d events, %d photos",
-			creditPackage.EventLimit,
-			creditPackage.PhotoLimit)),
-	}
-	prod, err := product.New(productParams)
-	if err != nil {
-		return nil, err
-	}
-
-	// Product için price oluştur
-	priceParams := &stripe.PriceParams{
-		Product:    stripe.String(prod.ID),
-		UnitAmount: stripe.Int64(int64(creditPackage.Price * 100)), // USD to cents
-		Currency:   stripe.String(string(stripe.CurrencyUSD)),
-	}
-	p, err := price.New(priceParams)
+	// Stripe'da geçici product ve price oluştur
+	priceID, err := createOneTimePrice(
+		creditPackage.Name,
+		fmt.Sprintf("%d events, %d photos", creditPackage.EventLimit, creditPackage.PhotoLimit),
+		creditPackage.Price,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -68,10 +97,11 @@ func (s *PaymentService) CreateCheckoutSession(userID uint, packageID uint) (*mo
 	// Checkout session oluştur
 	session, err := s.stripeService.CreateCheckoutSession(
 		user.Email,
-		p.ID,
+		priceID,
 		map[string]string{
-			"user_id":    fmt.Sprintf("%d", userID),
-			"package_id": fmt.Sprintf("%d", packageID),
+			purchaseTypeMetadataKey: purchaseTypeCredits,
+			"user_id":               fmt.Sprintf("%d", userID),
+			"package_id":            fmt.Sprintf("%d", packageID),
 		},
 	)
 	if err != nil {
@@ -109,6 +139,10 @@ func (s *PaymentService) HandleStripeWebhook(event *stripe.Event) error {
 			return err
 		}
 
+		if session.Metadata[purchaseTypeMetadataKey] == purchaseTypeExtension {
+			return s.completeEventExtension(session.ID)
+		}
+
 		// Metadata'dan user_id ve package_id'yi al
 		userID, err := strconv.ParseUint(session.Metadata["user_id"], 10, 32)
 		if err != nil {
@@ -145,6 +179,10 @@ func (s *PaymentService) HandleStripeWebhook(event *stripe.Event) error {
 			return err
 		}
 
+		if session.Metadata[purchaseTypeMetadataKey] == purchaseTypeExtension {
+			return s.setExtensionPurchaseStatus(session.ID, models.PurchaseStatusFailed)
+		}
+
 		// Purchase'ı bul ve güncelle
 		purchase, err := s.purchaseRepo.GetBySessionID(session.ID)
 		if err != nil {
@@ -168,6 +206,11 @@ func (s *PaymentService) HandleStripeWebhook(event *stripe.Event) error {
 				return nil // Bizim sistemimizle ilgisi yok
 			}
 
+			// Süre uzatma iadesinde etkinliğe eklenen süre veya kalıcı arşiv geri alınır
+			if _, err := s.extensionRepo.GetPurchaseBySessionID(sessionID); err == nil {
+				return s.refundEventExtension(sessionID)
+			}
+
 			// Purchase'ı bul ve güncelle
 			purchase, err := s.purchaseRepo.GetBySessionID(sessionID)
 			if err != nil {
@@ -240,3 +283,169 @@ func (s *PaymentService) ProcessSuccessfulPayment(userID uint, packageID uint) e
 	// Kullanıcıyı güncelle
 	return s.userRepo.Update(user)
 }
+
+func (s *PaymentService) GetEventExtensionPackages() ([]models.EventExtensionPackage, error) {
+	return s.extensionRepo.GetActivePackages()
+}
+
+func (s *PaymentService) GetEventExtensionHistory(userID uint) ([]models.EventExtensionPurchase, error) {
+	return s.extensionRepo.GetPurchasesByUserID(userID)
+}
+
+// CreateEventExtensionCheckout etkinlik sahibinin etkinliğinin süresini uzatmak için ödeme oturumu başlatır
+func (s *PaymentService) CreateEventExtensionCheckout(userID uint, eventURL string, packageID uint) (*models.CheckoutSession, error) {
+	event, err := s.eventRepo.GetByURL(eventURL)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	if event.UserID != userID {
+		return nil, errors.New("unauthorized")
+	}
+
+	if event.IsPermanent {
+		return nil, errors.New("event is already archived permanently")
+	}
+
+	extension, err := s.extensionRepo.GetPackageByID(packageID)
+	if err != nil {
+		return nil, errors.New("extension package not found")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	priceID, err := createOneTimePrice(
+		extension.Name,
+		fmt.Sprintf("%s for event \"%s\"", extension.Description, event.Title),
+		extension.Price,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.stripeService.CreateCheckoutSession(
+		user.Email,
+		priceID,
+		map[string]string{
+			purchaseTypeMetadataKey: purchaseTypeExtension,
+			"user_id":               fmt.Sprintf("%d", userID),
+			"event_id":              fmt.Sprintf("%d", event.ID),
+			"package_id":            fmt.Sprintf("%d", packageID),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	purchase := &models.EventExtensionPurchase{
+		UserID:          userID,
+		EventID:         event.ID,
+		PackageID:       extension.ID,
+		ExtensionDays:   extension.ExtensionDays,
+		IsPermanent:     extension.IsPermanent,
+		Price:           extension.Price,
+		StripeSessionID: session.ID,
+		Status:          models.PurchaseStatusPending,
+	}
+
+	if err := s.extensionRepo.CreatePurchase(purchase); err != nil {
+		return nil, err
+	}
+
+	return &models.CheckoutSession{
+		ID:  session.ID,
+		URL: session.URL,
+	}, nil
+}
+
+// completeEventExtension ödemesi tamamlanan süre uzatmasını etkinliğe uygular.
+// Stripe aynı webhook'u birden fazla gönderebileceği için tamamlanmış satın alma tekrar uygulanmaz.
+func (s *PaymentService) completeEventExtension(sessionID string) error {
+	purchase, err := s.extensionRepo.GetPurchaseBySessionID(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if purchase.Status == models.PurchaseStatusCompleted {
+		return nil
+	}
+
+	// Etkinlik ödeme tamamlanmadan kalıcı olarak silindiyse uzatma uygulanamaz
+	event, err := s.eventRepo.GetByIDUnscoped(purchase.EventID)
+	if err != nil {
+		fmt.Printf("Event %d for extension purchase %d not found: %v\n", purchase.EventID, purchase.ID, err)
+		purchase.Status = models.PurchaseStatusFailed
+		return s.extensionRepo.UpdatePurchase(purchase)
+	}
+
+	// Kalıcı arşiv bitiş tarihiyle değil ayrı bir bayrakla tutulur
+	if purchase.IsPermanent {
+		if err := s.eventRepo.MakePermanent(event.ID); err != nil {
+			return err
+		}
+	} else {
+		expiresAt := extendedExpiry(event.ExpiresAt, purchase.ExtensionDays, time.Now())
+		if err := s.eventRepo.Extend(event.ID, expiresAt); err != nil {
+			return err
+		}
+		purchase.NewExpiresAt = &expiresAt
+	}
+
+	purchase.Status = models.PurchaseStatusCompleted
+	return s.extensionRepo.UpdatePurchase(purchase)
+}
+
+// refundEventExtension iade edilen süre uzatmasını etkinlikten geri alır. Eklenen gün sayısı
+// bitiş tarihinden düşülür, kalıcı arşiv kaldırılır. Tamamlanmamış satın alma etkinliğe
+// uygulanmadığı için sadece işaretlenir.
+func (s *PaymentService) refundEventExtension(sessionID string) error {
+	purchase, err := s.extensionRepo.GetPurchaseBySessionID(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if purchase.Status == models.PurchaseStatusRefunded {
+		return nil
+	}
+
+	if purchase.Status == models.PurchaseStatusCompleted {
+		event, err := s.eventRepo.GetByIDUnscoped(purchase.EventID)
+		if err != nil {
+			fmt.Printf("Event %d for refunded extension purchase %d not found: %v\n", purchase.EventID, purchase.ID, err)
+		} else if purchase.IsPermanent {
+			if err := s.eventRepo.RevokePermanent(event.ID); err != nil {
+				return err
+			}
+		} else {
+			expiresAt := event.ExpiresAt.AddDate(0, 0, -purchase.ExtensionDays)
+			if err := s.eventRepo.ShortenExpiry(event.ID, expiresAt); err != nil {
+				return err
+			}
+		}
+	}
+
+	purchase.Status = models.PurchaseStatusRefunded
+	return s.extensionRepo.UpdatePurchase(purchase)
+}
+
+func (s *PaymentService) setExtensionPurchaseStatus(sessionID string, status string) error {
+	purchase, err := s.extensionRepo.GetPurchaseBySessionID(sessionID)
+	if err != nil {
+		return err
+	}
+
+	purchase.Status = status
+	return s.extensionRepo.UpdatePurchase(purchase)
+}
+
+// extendedExpiry satın alınan uzatmaya göre yeni bitiş tarihini hesaplar.
+// Süresi dolmuş etkinliklerde uzatma ödeme anından itibaren başlar.
+func extendedExpiry(current time.Time, days int, now time.Time) time.Time {
+	if current.Before(now) {
+		current = now
+	}
+	return current.AddDate(0, 0, days)
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExtendedExpiry(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		current time.Time
+		days    int
+		want    time.Time
+	}{
+		{"active event extends from current expiry", now.AddDate(0, 0, 10), 30, now.AddDate(0, 0, 40)},
+		{"expired event extends from now", now.AddDate(0, 0, -10), 30, now.AddDate(0, 0, 30)},
+		{"expiring exactly now extends from now", now, 7, now.AddDate(0, 0, 7)},
+		{"crosses month end", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC).AddDate(1, 0, 0), 1, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extendedExpiry(tt.current, tt.days, now); !got.Equal(tt.want) {
+				t.Errorf("extendedExpiry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
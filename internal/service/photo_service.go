@@ -3,24 +3,33 @@ package service
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/sefazor/ourphotos-backend/internal/models"
 	"github.com/sefazor/ourphotos-backend/internal/repository"
+	"github.com/sefazor/ourphotos-backend/pkg/realtime"
 	"github.com/sefazor/ourphotos-backend/pkg/storage"
 )
 
 type PhotoService struct {
-	photoRepo  *repository.PhotoRepository
-	eventRepo  *repository.EventRepository
-	userRepo   *repository.UserRepository
-	ImgStorage *storage.CloudflareImages
+	photoRepo    *repository.PhotoRepository
+	eventRepo    *repository.EventRepository
+	userRepo     *repository.UserRepository
+	reactionRepo *repository.ReactionRepository
+	commentRepo  *repository.CommentRepository
+	albumRepo    *repository.AlbumRepository
+	ImgStorage   *storage.CloudflareImages
+	feed         *realtime.Broker
 }
 
 func NewPhotoService(
@@ -28,16 +37,77 @@ func NewPhotoService(
 	eventRepo *repository.EventRepository,
 	ImgStorage *storage.CloudflareImages,
 	userRepo *repository.UserRepository,
+	reactionRepo *repository.ReactionRepository,
+	commentRepo *repository.CommentRepository,
+	albumRepo *repository.AlbumRepository,
+	feed *realtime.Broker,
 ) *PhotoService {
 	return &PhotoService{
-		photoRepo:  photoRepo,
-		eventRepo:  eventRepo,
-		userRepo:   userRepo,
-		ImgStorage: ImgStorage,
+		photoRepo:    photoRepo,
+		eventRepo:    eventRepo,
+		userRepo:     userRepo,
+		reactionRepo: reactionRepo,
+		commentRepo:  commentRepo,
+		albumRepo:    albumRepo,
+		ImgStorage:   ImgStorage,
+		feed:         feed,
 	}
 }
 
-func (s *PhotoService) UploadPhoto(eventID uint, userID uint, file *multipart.FileHeader) (*models.PhotoResponse, error) {
+// UploadOptions fotoğraf yüklenirken isteğe bağlı olarak gönderilen bilgileri taşır
+type UploadOptions struct {
+	Caption string
+	Tags    []string
+	AltText string
+
+	// Misafir yüklemeleri için anonim kimlik ve isteğe bağlı görünen ad
+	GuestID   string
+	GuestName string
+
+	// UploaderIP misafir başına yükleme sınırı için kullanılır, hashlenerek saklanır
+	UploaderIP string
+}
+
+const (
+	maxGuestNameLength = 50
+	maxCaptionLength   = 500
+	maxAltTextLength   = 300
+	maxTagCount        = 20
+	maxTagLength       = 30
+)
+
+// normalizeTags tagleri küçük harfe çevirir, boşlukları temizler ve tekrarları kaldırır
+func normalizeTags(tags []string) (models.StringList, error) {
+	normalized := models.StringList{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, fmt.Errorf("tags must be at most %d characters", maxTagLength)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > maxTagCount {
+		return nil, fmt.Errorf("a photo can have at most %d tags", maxTagCount)
+	}
+	return normalized, nil
+}
+
+func validatePhotoText(caption, altText string) error {
+	if utf8.RuneCountInString(caption) > maxCaptionLength {
+		return fmt.Errorf("caption must be at most %d characters", maxCaptionLength)
+	}
+	if utf8.RuneCountInString(altText) > maxAltTextLength {
+		return fmt.Errorf("alt text must be at most %d characters", maxAltTextLength)
+	}
+	return nil
+}
+
+func (s *PhotoService) UploadPhoto(eventID uint, userID uint, file *multipart.FileHeader, opts UploadOptions) (*models.PhotoResponse, error) {
 	fmt.Printf("UploadPhoto called - EventID: %d, UserID: %d\n", eventID, userID)
 
 	// Event'i bul
@@ -64,6 +134,17 @@ func (s *PhotoService) UploadPhoto(eventID uint, userID uint, file *multipart.Fi
 		return nil, errors.New("guest uploads are not allowed for this event")
 	}
 
+	// Etkinlik sahibinin belirlediği etkinlik ve misafir sınırları, sahibin kendi yüklemelerine uygulanmaz
+	var uploaderIPHash string
+	if userID == 0 && opts.UploaderIP != "" {
+		uploaderIPHash = hashDeviceToken(opts.UploaderIP)
+	}
+	if userID != event.UserID {
+		if err := s.checkEventUploadCaps(event, userID, opts.GuestID, uploaderIPHash); err != nil {
+			return nil, err
+		}
+	}
+
 	// Eğer giriş yapmış kullanıcı ise limit kontrolü yap
 	var user *models.User
 	if userID > 0 {
@@ -88,6 +169,21 @@ func (s *PhotoService) UploadPhoto(eventID uint, userID uint, file *multipart.Fi
 		fmt.Printf("Guest upload - no limit check needed\n")
 	}
 
+	// Açıklama ve tag bilgilerini doğrula
+	caption := strings.TrimSpace(opts.Caption)
+	altText := strings.TrimSpace(opts.AltText)
+	if err := validatePhotoText(caption, altText); err != nil {
+		return nil, err
+	}
+	tags, err := normalizeTags(opts.Tags)
+	if err != nil {
+		return nil, err
+	}
+	guestName := strings.TrimSpace(opts.GuestName)
+	if utf8.RuneCountInString(guestName) > maxGuestNameLength {
+		return nil, fmt.Errorf("guest name must be at most %d characters", maxGuestNameLength)
+	}
+
 	// Dosyayı aç
 	fileContent, err := file.Open()
 	if err != nil {
@@ -170,9 +266,20 @@ func (s *PhotoService) UploadPhoto(eventID uint, userID uint, file *multipart.Fi
 		ImageID:    imageID,
 		PublicURL:  s.ImgStorage.GetPublicURL(imageID),
 		IsGuest:    userID == 0,
+		Caption:    caption,
+		Tags:       tags,
+		AltText:    altText,
 		UploadedAt: time.Now(),
 	}
 
+	// Misafir yüklemesi ise misafir kimliğini kaydet, etkinlik onay gerektiriyorsa beklemeye al
+	if photo.IsGuest {
+		photo.GuestID = opts.GuestID
+		photo.GuestName = guestName
+		photo.UploaderIPHash = uploaderIPHash
+		photo.PendingApproval = event.RequireApproval
+	}
+
 	// Veritabanına kaydet
 	err = s.photoRepo.Create(photo)
 	if err != nil {
@@ -183,16 +290,21 @@ func (s *PhotoService) UploadPhoto(eventID uint, userID uint, file *multipart.Fi
 
 	// Response için URL'leri oluştur
 	response := &models.PhotoResponse{
-		ID:           photo.ID,
-		EventID:      photo.EventID,
-		UserID:       photo.UserID,
-		FileName:     photo.FileName,
-		FileSize:     photo.FileSize,
-		MimeType:     photo.MimeType,
-		PublicURL:    s.ImgStorage.GetPublicURL(photo.ImageID),
-		ThumbnailURL: s.ImgStorage.GetThumbnailURL(photo.ImageID),
-		IsGuest:      photo.IsGuest,
-		CreatedAt:    photo.UploadedAt,
+		ID:              photo.ID,
+		EventID:         photo.EventID,
+		UserID:          photo.UserID,
+		FileName:        photo.FileName,
+		FileSize:        photo.FileSize,
+		MimeType:        photo.MimeType,
+		PublicURL:       s.ImgStorage.GetPublicURL(photo.ImageID),
+		ThumbnailURL:    s.ImgStorage.GetThumbnailURL(photo.ImageID),
+		IsGuest:         photo.IsGuest,
+		GuestName:       photo.GuestName,
+		Caption:         photo.Caption,
+		Tags:            photo.Tags,
+		AltText:         photo.AltText,
+		PendingApproval: photo.PendingApproval,
+		CreatedAt:       photo.UploadedAt,
 	}
 
 	// ÖNEMLİ: Şimdi başarılı yüklemeden sonra limitleri düşür
@@ -224,10 +336,40 @@ func (s *PhotoService) UploadPhoto(eventID uint, userID uint, file *multipart.Fi
 		fmt.Printf("Warning: Failed to update event photo count: %v\n", err)
 	}
 
+	// 4. Canlı akışı izleyenlere yeni fotoğrafı bildir, onay bekleyenler onaylanınca yayınlanır
+	if !photo.PendingApproval {
+		s.publishPhoto(models.PhotoFeedCreated, *response)
+	}
+
 	return response, nil
 }
 
-func (s *PhotoService) GetEventPhotos(eventID uint, userID uint) ([]models.Photos, error) {
+// checkEventUploadCaps etkinliğin toplam ve misafir başına fotoğraf sınırlarını kontrol eder
+func (s *PhotoService) checkEventUploadCaps(event *models.Event, userID uint, guestID string, ipHash string) error {
+	if event.MaxPhotos > 0 {
+		count, err := s.photoRepo.CountByEventID(event.ID)
+		if err != nil {
+			return err
+		}
+		if count >= int64(event.MaxPhotos) {
+			return errors.New("event photo limit reached")
+		}
+	}
+
+	if userID == 0 && event.MaxPhotosPerGuest > 0 {
+		count, err := s.photoRepo.CountGuestUploads(event.ID, guestID, ipHash)
+		if err != nil {
+			return err
+		}
+		if count >= int64(event.MaxPhotosPerGuest) {
+			return errors.New("guest photo limit reached")
+		}
+	}
+
+	return nil
+}
+
+func (s *PhotoService) GetEventPhotos(eventID uint, userID uint, sort string, albumID uint) ([]models.Photos, error) {
 	// Önce event'in var olup olmadığını kontrol et
 	_, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
@@ -235,7 +377,10 @@ func (s *PhotoService) GetEventPhotos(eventID uint, userID uint) ([]models.Photo
 	}
 
 	// Fotoğrafları getir
-	photos, err := s.photoRepo.GetByEventID(eventID)
+	photos, err := s.photoRepo.ListByEventID(eventID, repository.PhotoListOptions{
+		Sort:    sort,
+		AlbumID: albumID,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get photos: %v", err)
 	}
@@ -243,35 +388,601 @@ func (s *PhotoService) GetEventPhotos(eventID uint, userID uint) ([]models.Photo
 	return photos, nil
 }
 
+// BuildPhotoResponses fotoğrafları beğeni sayılarıyla birlikte response modeline dönüştürür.
+// deviceToken boş değilse, cihazın beğendiği fotoğraflar LikedByMe ile işaretlenir.
+func (s *PhotoService) BuildPhotoResponses(photos []models.Photos, deviceToken string) ([]models.PhotoResponse, error) {
+	photoIDs := make([]uint, 0, len(photos))
+	for _, photo := range photos {
+		photoIDs = append(photoIDs, photo.ID)
+	}
+
+	likeCounts, err := s.reactionRepo.CountByPhotoIDs(photoIDs, models.ReactionHeart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+
+	commentCounts, err := s.commentRepo.CountByPhotoIDs(photoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	var likedByMe map[uint]bool
+	if deviceToken != "" {
+		likedByMe, err = s.reactionRepo.PhotoIDsReactedBy(hashDeviceToken(deviceToken), photoIDs, models.ReactionHeart)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get device reactions: %w", err)
+		}
+	}
+
+	responses := make([]models.PhotoResponse, 0, len(photos))
+	for _, photo := range photos {
+		response := s.toPhotoResponse(photo)
+		response.LikeCount = likeCounts[photo.ID]
+		response.LikedByMe = likedByMe[photo.ID]
+		response.CommentCount = commentCounts[photo.ID]
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// publishPhoto fotoğrafı etkinliğin canlı akışına yayınlar
+func (s *PhotoService) publishPhoto(messageType string, photo models.PhotoResponse) {
+	if s.feed == nil {
+		return
+	}
+
+	payload, err := json.Marshal(models.PhotoFeedMessage{
+		Type:  messageType,
+		Photo: photo,
+	})
+	if err != nil {
+		fmt.Printf("Error encoding live feed message for photo %d: %v\n", photo.ID, err)
+		return
+	}
+
+	s.feed.Publish(photo.EventID, payload)
+}
+
+func (s *PhotoService) toPhotoResponse(photo models.Photos) models.PhotoResponse {
+	return models.PhotoResponse{
+		ID:              photo.ID,
+		EventID:         photo.EventID,
+		UserID:          photo.UserID,
+		FileName:        photo.FileName,
+		FileSize:        photo.FileSize,
+		MimeType:        photo.MimeType,
+		PublicURL:       photo.PublicURL,
+		ThumbnailURL:    s.ImgStorage.GetThumbnailURL(photo.ImageID),
+		IsGuest:         photo.IsGuest,
+		GuestName:       photo.GuestName,
+		Caption:         photo.Caption,
+		Tags:            photo.Tags,
+		AltText:         photo.AltText,
+		IsHidden:        photo.Hidden(),
+		OwnerHidden:     photo.OwnerHidden,
+		AlbumID:         photo.AlbumID,
+		PendingApproval: photo.PendingApproval,
+		CreatedAt:       photo.CreatedAt,
+	}
+}
+
+// UpdatePhotoMetadata fotoğrafın açıklama, tag ve alt text alanlarını günceller.
+// Sadece etkinlik sahibi veya fotoğrafı yükleyen kullanıcı düzenleyebilir.
+func (s *PhotoService) UpdatePhotoMetadata(photoID uint, userID uint, req models.UpdatePhotoMetadataRequest) (*models.PhotoResponse, error) {
+	photo, err := s.photoRepo.GetByID(photoID)
+	if err != nil {
+		return nil, errors.New("photo not found")
+	}
+
+	event, err := s.eventRepo.GetByID(photo.EventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	// Yetki kontrolü
+	if event.UserID != userID && (photo.UserID == 0 || photo.UserID != userID) {
+		return nil, errors.New("unauthorized")
+	}
+
+	if err := s.applyMetadata(photo, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.photoRepo.Update(photo); err != nil {
+		return nil, err
+	}
+
+	response := s.toPhotoResponse(*photo)
+	return &response, nil
+}
+
+func (s *PhotoService) applyMetadata(photo *models.Photos, req models.UpdatePhotoMetadataRequest) error {
+	caption := photo.Caption
+	if req.Caption != nil {
+		caption = strings.TrimSpace(*req.Caption)
+	}
+	altText := photo.AltText
+	if req.AltText != nil {
+		altText = strings.TrimSpace(*req.AltText)
+	}
+	if err := validatePhotoText(caption, altText); err != nil {
+		return err
+	}
+
+	if req.Tags != nil {
+		tags, err := normalizeTags(*req.Tags)
+		if err != nil {
+			return err
+		}
+		photo.Tags = tags
+	}
+	photo.Caption = caption
+	photo.AltText = altText
+	return nil
+}
+
+// SearchPhotos kullanıcının tüm etkinliklerinde fotoğraf araması yapar
+func (s *PhotoService) SearchPhotos(userID uint, query string, page, limit int) ([]models.PhotoSearchResult, int64, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, 0, errors.New("search query is required")
+	}
+
+	rows, total, err := s.photoRepo.SearchUserPhotos(userID, query, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search photos: %w", err)
+	}
+
+	photos := make([]models.Photos, 0, len(rows))
+	for _, row := range rows {
+		photos = append(photos, row.Photos)
+	}
+
+	responses, err := s.BuildPhotoResponses(photos, "")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]models.PhotoSearchResult, 0, len(rows))
+	for i, row := range rows {
+		results = append(results, models.PhotoSearchResult{
+			PhotoResponse: responses[i],
+			EventURL:      row.EventURL,
+			EventTitle:    row.EventTitle,
+		})
+	}
+
+	return results, total, nil
+}
+
 func (s *PhotoService) DeletePhoto(photoID uint, userID uint) error {
 	photo, err := s.photoRepo.GetByID(photoID)
 	if err != nil {
 		return fmt.Errorf("photo not found: %w", err)
 	}
 
-	// Yetki kontrolü
-	if photo.UserID != userID {
+	event, err := s.eventRepo.GetByID(photo.EventID)
+	if err != nil {
+		return errors.New("event not found")
+	}
+
+	// Yetki kontrolü: fotoğrafı yükleyen kullanıcı veya etkinlik sahibi silebilir
+	if !canDeletePhoto(photo, event, userID) {
 		return errors.New("unauthorized")
 	}
 
-	// Cloudflare Images'dan sil
+	return s.removePhoto(photo)
+}
+
+func canDeletePhoto(photo *models.Photos, event *models.Event, userID uint) bool {
+	return event.UserID == userID || (photo.UserID != 0 && photo.UserID == userID)
+}
+
+// removePhoto fotoğrafı çöp kutusuna taşır. Depolama servisindeki görsel ve ilişkili kayıtlar
+// saklama süresi dolunca purgePhoto ile kalıcı olarak silinir.
+func (s *PhotoService) removePhoto(photo *models.Photos) error {
+	return s.photoRepo.Delete(photo.ID)
+}
+
+// PurgePhotos çöp kutusundaki fotoğrafları kalıcı olarak siler ve silinen fotoğraf sayısını döndürür
+func (s *PhotoService) PurgePhotos(photos []models.Photos) int {
+	var mu sync.Mutex
+	purged := 0
+
+	forEachBounded(len(photos), func(i int) {
+		photo := &photos[i]
+		if err := s.purgePhoto(photo); err != nil {
+			fmt.Printf("Error purging photo %d: %v\n", photo.ID, err)
+			return
+		}
+		mu.Lock()
+		purged++
+		mu.Unlock()
+	})
+
+	return purged
+}
+
+// purgePhoto fotoğrafı depolama servisinden ve ilişkili kayıtlarıyla birlikte veritabanından kalıcı olarak siler
+func (s *PhotoService) purgePhoto(photo *models.Photos) error {
+	// Cloudflare Images'dan sil, başarısız olursa kayıt bir sonraki temizlikte tekrar denenir
 	if err := s.ImgStorage.Delete(photo.ImageID); err != nil {
 		return fmt.Errorf("failed to delete from image service: %w", err)
 	}
 
-	// Veritabanından sil
-	return s.photoRepo.Delete(photoID)
+	// Fotoğrafa ait reaksiyon ve yorumları temizle
+	if err := s.reactionRepo.DeleteByPhotoID(photo.ID); err != nil {
+		fmt.Printf("Error deleting reactions for photo %d: %v\n", photo.ID, err)
+	}
+	if err := s.commentRepo.DeleteByPhotoID(photo.ID); err != nil {
+		fmt.Printf("Error deleting comments for photo %d: %v\n", photo.ID, err)
+	}
+
+	// Kapak fotoğrafı olarak kullanılıyorsa kapağı kaldır
+	if err := s.eventRepo.ClearCoverPhoto(photo.ID); err != nil {
+		fmt.Printf("Error clearing cover photo %d: %v\n", photo.ID, err)
+	}
+
+	// Veritabanından kalıcı olarak sil
+	return s.photoRepo.HardDelete(photo.ID)
+}
+
+// PurgeEventContent etkinliğin çöp kutusundakiler dahil tüm fotoğraflarını, kapak görselini, albümlerini,
+// reaksiyon ve yorumlarını kalıcı olarak siler. Silinen fotoğraf sayısını döndürür.
+func (s *PhotoService) PurgeEventContent(event *models.Event) (int, error) {
+	eventID := event.ID
+	photos, err := s.photoRepo.GetAllByEventID(eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve event photos: %w", err)
+	}
+
+	// Her bir fotoğrafı depolama servislerinden sil
+	forEachBounded(len(photos), func(i int) {
+		photo := &photos[i]
+		if err := s.ImgStorage.Delete(photo.ImageID); err != nil {
+			// Hata loga kaydedilmeli ama işleme devam edilmeli
+			fmt.Printf("Error deleting photo %s from Cloudflare Images: %v\n", photo.ImageID, err)
+		}
+	})
+	if event.CoverImageID != "" {
+		if err := s.ImgStorage.Delete(event.CoverImageID); err != nil {
+			fmt.Printf("Error deleting cover image %s from Cloudflare Images: %v\n", event.CoverImageID, err)
+		}
+	}
+
+	// Fotoğraflara ait reaksiyon, yorum ve albümleri sil
+	if err := s.reactionRepo.DeleteByEventID(eventID); err != nil {
+		return 0, fmt.Errorf("failed to delete event reactions: %w", err)
+	}
+	if err := s.commentRepo.DeleteByEventID(eventID); err != nil {
+		return 0, fmt.Errorf("failed to delete event comments: %w", err)
+	}
+	if err := s.albumRepo.DeleteByEventID(eventID); err != nil {
+		return 0, fmt.Errorf("failed to delete event albums: %w", err)
+	}
+
+	// Veritabanından tüm fotoğrafları sil
+	if err := s.photoRepo.HardDeleteByEventID(eventID); err != nil {
+		return 0, fmt.Errorf("failed to delete event photos from database: %w", err)
+	}
+
+	return len(photos), nil
+}
+
+// storageDeleteConcurrency depolama servisine aynı anda gönderilen en fazla silme isteği sayısı
+const storageDeleteConcurrency = 8
+
+// forEachBounded fn'i 0..n-1 indeksleri için en fazla storageDeleteConcurrency eşzamanlı goroutine ile çalıştırır
+func forEachBounded(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, storageDeleteConcurrency)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+// bulkPhotoItem yetki kontrolünden geçen fotoğrafı sonuç listesindeki yeriyle birlikte taşır
+type bulkPhotoItem struct {
+	index int
+	photo *models.Photos
+	event *models.Event
+}
+
+// BulkPhotoOperation birden fazla fotoğrafa aynı işlemi uygular ve her fotoğraf için ayrı sonuç döndürür.
+// Yetki her fotoğraf için ayrı kontrol edilir: işlemler etkinlik sahibine açıktır, silme işlemini
+// fotoğrafı yükleyen kullanıcı da yapabilir.
+func (s *PhotoService) BulkPhotoOperation(userID uint, req models.BulkPhotoRequest) (*models.BulkPhotoResponse, error) {
+	// Tekrarlanan ID'leri sırayı koruyarak ayıkla
+	photoIDs := make([]uint, 0, len(req.PhotoIDs))
+	seen := make(map[uint]bool)
+	for _, id := range req.PhotoIDs {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		photoIDs = append(photoIDs, id)
+	}
+	if len(photoIDs) == 0 {
+		return nil, errors.New("photo_ids is required")
+	}
+	if len(photoIDs) > models.MaxBulkPhotoIDs {
+		return nil, fmt.Errorf("at most %d photos can be processed at once", models.MaxBulkPhotoIDs)
+	}
+
+	if req.Action == models.BulkActionSetCover && len(photoIDs) != 1 {
+		return nil, errors.New("set_cover requires exactly one photo")
+	}
+
+	var album *models.PhotoAlbum
+	if req.Action == models.BulkActionMoveToAlbum {
+		if req.AlbumID == nil {
+			return nil, errors.New("album_id is required")
+		}
+		if *req.AlbumID > 0 {
+			var err error
+			album, err = s.albumRepo.GetByID(*req.AlbumID)
+			if err != nil {
+				return nil, errors.New("album not found")
+			}
+		}
+	}
+
+	photos, err := s.photoRepo.GetByIDs(photoIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+	photosByID := make(map[uint]*models.Photos, len(photos))
+	for i := range photos {
+		photosByID[photos[i].ID] = &photos[i]
+	}
+
+	results := make([]models.BulkPhotoItemResult, len(photoIDs))
+	events := make(map[uint]*models.Event)
+	items := make([]bulkPhotoItem, 0, len(photoIDs))
+
+	for i, id := range photoIDs {
+		results[i].PhotoID = id
+
+		photo, ok := photosByID[id]
+		if !ok {
+			results[i].Error = "photo not found"
+			continue
+		}
+
+		event, ok := events[photo.EventID]
+		if !ok {
+			event, err = s.eventRepo.GetByID(photo.EventID)
+			if err != nil {
+				event = nil
+			}
+			events[photo.EventID] = event
+		}
+		if event == nil {
+			results[i].Error = "event not found"
+			continue
+		}
+
+		// Yetki kontrolü
+		authorized := event.UserID == userID
+		if req.Action == models.BulkActionDelete {
+			authorized = canDeletePhoto(photo, event, userID)
+		}
+		if !authorized {
+			results[i].Error = "unauthorized"
+			continue
+		}
+
+		if album != nil && album.EventID != photo.EventID {
+			results[i].Error = "album belongs to another event"
+			continue
+		}
+
+		items = append(items, bulkPhotoItem{index: i, photo: photo, event: event})
+	}
+
+	switch req.Action {
+	case models.BulkActionDelete:
+		// Fotoğraflar çöp kutusuna taşınır, depolama servisinden silme purge işinde yapılır
+		s.markBulkResults(items, results, s.photoRepo.DeleteByIDs(bulkPhotoIDs(items)), "failed to delete photo")
+	case models.BulkActionHide, models.BulkActionUnhide:
+		// Sadece sahibin gizlemesi değişir, şikayet sonrası moderasyon gizlemesi admin kararıyla kalkar
+		s.bulkUpdate(items, results, map[string]interface{}{
+			"owner_hidden": req.Action == models.BulkActionHide,
+		})
+	case models.BulkActionMoveToAlbum:
+		var albumID interface{}
+		if album != nil {
+			albumID = album.ID
+		}
+		s.bulkUpdate(items, results, map[string]interface{}{
+			"album_id": albumID,
+		})
+	case models.BulkActionApprove:
+		if s.bulkUpdate(items, results, map[string]interface{}{"pending_approval": false}) {
+			// Onaylanan fotoğraflardan görünür olanları canlı akışa yayınla
+			for _, item := range items {
+				if !item.photo.PendingApproval {
+					continue
+				}
+				item.photo.PendingApproval = false
+				if !item.photo.Hidden() {
+					s.publishPhoto(models.PhotoFeedCreated, s.toPhotoResponse(*item.photo))
+				}
+			}
+		}
+	case models.BulkActionSetCover:
+		for _, item := range items {
+			item.event.CoverPhotoID = &item.photo.ID
+			if err := s.eventRepo.Update(item.event); err != nil {
+				results[item.index].Error = err.Error()
+				continue
+			}
+			results[item.index].Success = true
+		}
+	default:
+		return nil, errors.New("invalid bulk action")
+	}
+
+	response := &models.BulkPhotoResponse{
+		Action:  req.Action,
+		Results: results,
+	}
+	for _, result := range results {
+		if result.Success {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+	}
+
+	return response, nil
+}
+
+func bulkPhotoIDs(items []bulkPhotoItem) []uint {
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.photo.ID)
+	}
+	return ids
+}
+
+// bulkUpdate fotoğrafları tek sorguda günceller ve sonuçları işaretler. Güncelleme başarılıysa true döner.
+func (s *PhotoService) bulkUpdate(items []bulkPhotoItem, results []models.BulkPhotoItemResult, updates map[string]interface{}) bool {
+	return s.markBulkResults(items, results, s.photoRepo.UpdateByIDs(bulkPhotoIDs(items), updates), "failed to update photo")
+}
+
+// markBulkResults tek sorguda yapılan toplu işlemin sonucunu her fotoğraf için işaretler
+func (s *PhotoService) markBulkResults(items []bulkPhotoItem, results []models.BulkPhotoItemResult, err error, failure string) bool {
+	if err != nil {
+		fmt.Printf("Error processing photos in bulk: %v\n", err)
+	}
+	for _, item := range items {
+		if err != nil {
+			results[item.index].Error = failure
+			continue
+		}
+		results[item.index].Success = true
+	}
+	return err == nil
+}
+
+// getGuestPhoto fotoğrafın ilgili etkinliğe ait olduğunu ve misafir tarafından yüklendiğini doğrular
+func (s *PhotoService) getGuestPhoto(eventID uint, photoID uint, guestID string) (*models.Photos, error) {
+	photo, err := s.photoRepo.GetByID(photoID)
+	if err != nil || photo.EventID != eventID {
+		return nil, errors.New("photo not found")
+	}
+
+	if guestID == "" || photo.GuestID != guestID {
+		return nil, errors.New("unauthorized")
+	}
+
+	return photo, nil
+}
+
+// GetGuestUploads misafirin etkinliğe yüklediği fotoğrafları döndürür
+func (s *PhotoService) GetGuestUploads(eventID uint, guestID string) ([]models.Photos, error) {
+	return s.photoRepo.GetByEventIDAndGuestID(eventID, guestID)
+}
+
+// DeleteGuestPhoto misafirin kendi yüklediği bir fotoğrafı silmesini sağlar
+func (s *PhotoService) DeleteGuestPhoto(eventID uint, photoID uint, guestID string) error {
+	photo, err := s.getGuestPhoto(eventID, photoID, guestID)
+	if err != nil {
+		return err
+	}
+
+	return s.removePhoto(photo)
+}
+
+// UpdateGuestPhotoMetadata misafirin kendi yüklediği fotoğrafın açıklama ve taglerini düzenlemesini sağlar
+func (s *PhotoService) UpdateGuestPhotoMetadata(eventID uint, photoID uint, guestID string, req models.UpdatePhotoMetadataRequest) (*models.PhotoResponse, error) {
+	photo, err := s.getGuestPhoto(eventID, photoID, guestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyMetadata(photo, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.photoRepo.Update(photo); err != nil {
+		return nil, err
+	}
+
+	response := s.toPhotoResponse(*photo)
+	return &response, nil
+}
+
+// GetUploadsByGuest etkinlik sahibine misafir yüklemelerini misafir bazında gruplanmış olarak döndürür
+func (s *PhotoService) GetUploadsByGuest(eventID uint, userID uint) ([]models.GuestUploadGroup, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	// Yetki kontrolü
+	if event.UserID != userID {
+		return nil, errors.New("unauthorized")
+	}
+
+	photos, err := s.photoRepo.GetGuestPhotosByEventID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+
+	responses, err := s.BuildPhotoResponses(photos, "")
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]models.GuestUploadGroup, 0)
+	groupIndex := make(map[string]int)
+	for i, photo := range photos {
+		idx, ok := groupIndex[photo.GuestID]
+		if !ok {
+			idx = len(groups)
+			groupIndex[photo.GuestID] = idx
+			groups = append(groups, models.GuestUploadGroup{
+				GuestID: photo.GuestID,
+				Photos:  []models.PhotoResponse{},
+			})
+		}
+
+		// Misafirin en son kullandığı isim gösterilir
+		if groups[idx].GuestName == "" && photo.GuestName != "" {
+			groups[idx].GuestName = photo.GuestName
+		}
+		groups[idx].Photos = append(groups[idx].Photos, responses[i])
+		groups[idx].PhotoCount++
+	}
+
+	return groups, nil
 }
 
-func (s *PhotoService) GetPublicEventPhotos(eventURL string) ([]models.Photos, error) {
+func (s *PhotoService) GetPublicEventPhotos(eventURL string, sort string, albumID uint) ([]models.Photos, error) {
 	// Önce event'i bul
 	event, err := s.eventRepo.GetByURL(eventURL)
 	if err != nil {
 		return nil, errors.New("event not found")
 	}
 
-	// Event'in fotoğraflarını getir
-	return s.photoRepo.GetByEventID(event.ID)
+	// Event'in gizlenmemiş ve onaylanmış fotoğraflarını getir
+	return s.photoRepo.ListByEventID(event.ID, repository.PhotoListOptions{
+		Sort:       sort,
+		PublicOnly: true,
+		AlbumID:    albumID,
+	})
 }
 
 func (s *PhotoService) GetEventPhotoCount(eventID uint) (int64, error) {
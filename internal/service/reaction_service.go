@@ -0,0 +1,100 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/repository"
+)
+
+const (
+	minDeviceTokenLength = 16
+	maxDeviceTokenLength = 128
+)
+
+type ReactionService struct {
+	reactionRepo *repository.ReactionRepository
+	photoRepo    *repository.PhotoRepository
+}
+
+func NewReactionService(reactionRepo *repository.ReactionRepository, photoRepo *repository.PhotoRepository) *ReactionService {
+	return &ReactionService{
+		reactionRepo: reactionRepo,
+		photoRepo:    photoRepo,
+	}
+}
+
+// hashDeviceToken cihaz tokenini veritabanında açık halde tutmamak için hashler
+func hashDeviceToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func validateDeviceToken(token string) error {
+	if len(token) < minDeviceTokenLength || len(token) > maxDeviceTokenLength {
+		return errors.New("invalid device token")
+	}
+	return nil
+}
+
+// AddReaction misafirin fotoğrafı beğenmesini kaydeder. Aynı cihazdan gelen tekrar istekler sayacı artırmaz.
+func (s *ReactionService) AddReaction(eventID uint, photoID uint, deviceToken string) (*models.ReactionResponse, error) {
+	if err := validateDeviceToken(deviceToken); err != nil {
+		return nil, err
+	}
+
+	photo, err := s.photoRepo.GetByID(photoID)
+	if err != nil || photo.EventID != eventID {
+		return nil, errors.New("photo not found")
+	}
+
+	reaction := &models.PhotoReaction{
+		PhotoID:     photo.ID,
+		EventID:     photo.EventID,
+		Type:        models.ReactionHeart,
+		DeviceToken: hashDeviceToken(deviceToken),
+	}
+	if err := s.reactionRepo.Create(reaction); err != nil {
+		return nil, err
+	}
+
+	count, err := s.reactionRepo.CountByPhotoID(photo.ID, models.ReactionHeart)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ReactionResponse{
+		PhotoID:   photo.ID,
+		LikeCount: count,
+		LikedByMe: true,
+	}, nil
+}
+
+// RemoveReaction misafirin daha önce verdiği beğeniyi geri alır
+func (s *ReactionService) RemoveReaction(eventID uint, photoID uint, deviceToken string) (*models.ReactionResponse, error) {
+	if err := validateDeviceToken(deviceToken); err != nil {
+		return nil, err
+	}
+
+	photo, err := s.photoRepo.GetByID(photoID)
+	if err != nil || photo.EventID != eventID {
+		return nil, errors.New("photo not found")
+	}
+
+	if err := s.reactionRepo.Delete(photo.ID, models.ReactionHeart, hashDeviceToken(deviceToken)); err != nil {
+		return nil, err
+	}
+
+	count, err := s.reactionRepo.CountByPhotoID(photo.ID, models.ReactionHeart)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ReactionResponse{
+		PhotoID:   photo.ID,
+		LikeCount: count,
+		LikedByMe: false,
+	}, nil
+}
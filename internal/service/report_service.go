@@ -0,0 +1,218 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/repository"
+	"github.com/sefazor/ourphotos-backend/pkg/email"
+	"github.com/sefazor/ourphotos-backend/pkg/storage"
+)
+
+type ReportService struct {
+	reportRepo    *repository.ReportRepository
+	photoRepo     *repository.PhotoRepository
+	eventRepo     *repository.EventRepository
+	userRepo      *repository.UserRepository
+	emailService  *email.EmailService
+	ImgStorage    *storage.CloudflareImages
+	hideThreshold int
+}
+
+func NewReportService(
+	reportRepo *repository.ReportRepository,
+	photoRepo *repository.PhotoRepository,
+	eventRepo *repository.EventRepository,
+	userRepo *repository.UserRepository,
+	emailService *email.EmailService,
+	ImgStorage *storage.CloudflareImages,
+	hideThreshold int,
+) *ReportService {
+	return &ReportService{
+		reportRepo:    reportRepo,
+		photoRepo:     photoRepo,
+		eventRepo:     eventRepo,
+		userRepo:      userRepo,
+		emailService:  emailService,
+		ImgStorage:    ImgStorage,
+		hideThreshold: hideThreshold,
+	}
+}
+
+// ReportPhoto public galerideki bir fotoğraf için şikayet oluşturur. Ziyaretçi IP adresi ve cihaz
+// tokeniyle tanımlanır, sadece hash'leri saklanır. Cihaz tokeni istemci tarafından seçildiğinden
+// gizleme eşiği farklı IP adreslerinin sayısına göre hesaplanır. Eşiğe ulaşan fotoğraf galeriden gizlenir.
+func (s *ReportService) ReportPhoto(event *models.Event, photoID uint, reporterIP, deviceToken string, req models.CreateReportRequest) (*models.ReportResponse, error) {
+	photo, err := s.photoRepo.GetByID(photoID)
+	if err != nil || photo.EventID != event.ID {
+		return nil, errors.New("photo not found")
+	}
+
+	report := &models.PhotoReport{
+		PhotoID:      photo.ID,
+		EventID:      event.ID,
+		Reason:       req.Reason,
+		Message:      strings.TrimSpace(req.Message),
+		ReporterHash: hashDeviceToken(reporterIP + "|" + deviceToken),
+		ReporterIP:   hashDeviceToken(reporterIP),
+		Status:       models.ReportStatusOpen,
+	}
+
+	created, err := s.reportRepo.Create(report)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, errors.New("photo already reported")
+	}
+
+	openCount, err := s.reportRepo.CountOpenByPhotoID(photo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	reporterIPs, err := s.reportRepo.CountOpenReporterIPsByPhotoID(photo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Eşik aşıldıysa fotoğrafı inceleme bitene kadar gizle
+	autoHidden := false
+	if s.hideThreshold > 0 && reporterIPs >= int64(s.hideThreshold) && !photo.IsHidden {
+		if err := s.photoRepo.SetHidden(photo.ID, true); err != nil {
+			return nil, err
+		}
+		photo.IsHidden = true
+		autoHidden = true
+	}
+
+	// Sahibin gelen kutusunu korumak için inceleme döneminde sadece ilk şikayette ve
+	// fotoğraf otomatik gizlendiğinde bildirim gönderilir
+	if openCount == 1 || autoHidden {
+		if owner, err := s.userRepo.GetByID(event.UserID); err == nil {
+			go s.emailService.SendPhotoReportedEmail(
+				owner.Email,
+				owner.FullName,
+				event.Title,
+				event.URL,
+				report.Reason,
+				report.Message,
+				s.ImgStorage.GetThumbnailURL(photo.ImageID),
+				photo.IsHidden,
+			)
+		} else {
+			fmt.Printf("Warning: could not load owner of event %d for report notification: %v\n", event.ID, err)
+		}
+	}
+
+	response := s.toReportResponse(*report, photo, event, openCount)
+	return &response, nil
+}
+
+// ListReports admin paneli için şikayetleri duruma göre sayfalı listeler
+func (s *ReportService) ListReports(status string, page, limit int) ([]models.ReportResponse, int64, error) {
+	switch status {
+	case "", models.ReportStatusOpen, models.ReportStatusResolved, models.ReportStatusDismissed:
+	default:
+		return nil, 0, errors.New("invalid report status")
+	}
+
+	reports, total, err := s.reportRepo.List(status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	photos := make(map[uint]*models.Photos)
+	events := make(map[uint]*models.Event)
+	openCounts := make(map[uint]int64)
+
+	responses := make([]models.ReportResponse, 0, len(reports))
+	for _, report := range reports {
+		photo, ok := photos[report.PhotoID]
+		if !ok {
+			photo, _ = s.photoRepo.GetByID(report.PhotoID)
+			photos[report.PhotoID] = photo
+			openCounts[report.PhotoID], _ = s.reportRepo.CountOpenByPhotoID(report.PhotoID)
+		}
+
+		event, ok := events[report.EventID]
+		if !ok {
+			event, _ = s.eventRepo.GetByID(report.EventID)
+			events[report.EventID] = event
+		}
+
+		responses = append(responses, s.toReportResponse(report, photo, event, openCounts[report.PhotoID]))
+	}
+
+	return responses, total, nil
+}
+
+// ResolveReport şikayeti haklı bulur. Fotoğrafa ait tüm açık şikayetler kapatılır ve
+// fotoğraf public galeriden gizli tutulur.
+func (s *ReportService) ResolveReport(reportID uint, adminID uint) error {
+	report, err := s.getOpenReport(reportID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.reportRepo.CloseOpenByPhotoID(report.PhotoID, models.ReportStatusResolved, adminID); err != nil {
+		return err
+	}
+
+	return s.photoRepo.SetHidden(report.PhotoID, true)
+}
+
+// DismissReport şikayeti reddeder. Fotoğrafa ait tüm açık şikayetler kapatılır ve
+// moderasyon gizlemesi kaldırılır, etkinlik sahibinin gizlediği fotoğraf gizli kalır.
+func (s *ReportService) DismissReport(reportID uint, adminID uint) error {
+	report, err := s.getOpenReport(reportID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.reportRepo.CloseOpenByPhotoID(report.PhotoID, models.ReportStatusDismissed, adminID); err != nil {
+		return err
+	}
+
+	return s.photoRepo.SetHidden(report.PhotoID, false)
+}
+
+func (s *ReportService) getOpenReport(reportID uint) (*models.PhotoReport, error) {
+	report, err := s.reportRepo.GetByID(reportID)
+	if err != nil {
+		return nil, errors.New("report not found")
+	}
+	if report.Status != models.ReportStatusOpen {
+		return nil, errors.New("report already closed")
+	}
+	return report, nil
+}
+
+func (s *ReportService) toReportResponse(report models.PhotoReport, photo *models.Photos, event *models.Event, openCount int64) models.ReportResponse {
+	response := models.ReportResponse{
+		ID:              report.ID,
+		PhotoID:         report.PhotoID,
+		EventID:         report.EventID,
+		OpenReportCount: openCount,
+		Reason:          report.Reason,
+		Message:         report.Message,
+		Status:          report.Status,
+		ResolvedAt:      report.ResolvedAt,
+		CreatedAt:       report.CreatedAt,
+	}
+
+	// Fotoğraf veya etkinlik silinmiş olabilir
+	if photo != nil {
+		response.PhotoURL = photo.PublicURL
+		response.ThumbnailURL = s.ImgStorage.GetThumbnailURL(photo.ImageID)
+		response.PhotoHidden = photo.IsHidden
+	}
+	if event != nil {
+		response.EventURL = event.URL
+		response.EventTitle = event.Title
+	}
+
+	return response
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sefazor/ourphotos-backend/internal/models"
+	"github.com/sefazor/ourphotos-backend/internal/repository"
+)
+
+// trashPurgeBatchSize tek temizlik turunda kalıcı olarak silinecek en fazla fotoğraf sayısı
+const trashPurgeBatchSize = 500
+
+type TrashService struct {
+	eventRepo    *repository.EventRepository
+	photoRepo    *repository.PhotoRepository
+	userRepo     *repository.UserRepository
+	purgeLogRepo *repository.PurgeLogRepository
+	photoService *PhotoService
+	retention    time.Duration
+}
+
+func NewTrashService(
+	eventRepo *repository.EventRepository,
+	photoRepo *repository.PhotoRepository,
+	userRepo *repository.UserRepository,
+	purgeLogRepo *repository.PurgeLogRepository,
+	photoService *PhotoService,
+	retentionDays int,
+) *TrashService {
+	return &TrashService{
+		eventRepo:    eventRepo,
+		photoRepo:    photoRepo,
+		userRepo:     userRepo,
+		purgeLogRepo: purgeLogRepo,
+		photoService: photoService,
+		retention:    time.Duration(retentionDays) * 24 * time.Hour,
+	}
+}
+
+// ListTrashedEvents kullanıcının çöp kutusundaki etkinliklerini döndürür
+func (s *TrashService) ListTrashedEvents(userID uint) ([]models.TrashedEventResponse, error) {
+	events, err := s.eventRepo.GetDeletedByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.TrashedEventResponse, 0, len(events))
+	for _, event := range events {
+		responses = append(responses, models.TrashedEventResponse{
+			ID:         event.ID,
+			Title:      event.Title,
+			URL:        event.URL,
+			PhotoCount: event.PhotoCount,
+			DeletedAt:  event.DeletedAt.Time,
+			PurgeAt:    event.DeletedAt.Time.Add(s.retention),
+		})
+	}
+
+	return responses, nil
+}
+
+// ListTrashedPhotos kullanıcının etkinliklerinden silinen fotoğrafları sayfalı olarak döndürür
+func (s *TrashService) ListTrashedPhotos(userID uint, page, limit int) ([]models.TrashedPhotoResponse, int64, error) {
+	rows, total, err := s.photoRepo.GetDeletedByUserID(userID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get deleted photos: %w", err)
+	}
+
+	responses := make([]models.TrashedPhotoResponse, 0, len(rows))
+	for _, row := range rows {
+		responses = append(responses, models.TrashedPhotoResponse{
+			PhotoResponse: s.photoService.toPhotoResponse(row.Photos),
+			EventURL:      row.EventURL,
+			EventTitle:    row.EventTitle,
+			DeletedAt:     row.DeletedAt.Time,
+			PurgeAt:       row.DeletedAt.Time.Add(s.retention),
+		})
+	}
+
+	return responses, total, nil
+}
+
+// RestoreEvent çöp kutusundaki etkinliği fotoğraflarıyla birlikte geri yükler.
+// Silme sırasında iade edilen etkinlik hakkı tekrar kullanılır.
+func (s *TrashService) RestoreEvent(eventID uint, userID uint) error {
+	event, err := s.eventRepo.GetDeletedByID(eventID)
+	if err != nil {
+		return errors.New("event not found")
+	}
+
+	// Yetki kontrolü
+	if event.UserID != userID {
+		return errors.New("unauthorized")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.EventLimit <= 0 {
+		return errors.New("event limit exceeded")
+	}
+
+	if err := s.eventRepo.Restore(event.ID); err != nil {
+		return err
+	}
+
+	user.EventLimit--
+	return s.userRepo.Update(user)
+}
+
+// RestorePhoto çöp kutusundaki fotoğrafı geri yükler. Etkinlik de silinmişse önce etkinlik geri yüklenmelidir.
+func (s *TrashService) RestorePhoto(photoID uint, userID uint) error {
+	photo, err := s.photoRepo.GetDeletedByID(photoID)
+	if err != nil {
+		return errors.New("photo not found")
+	}
+
+	event, err := s.eventRepo.GetByID(photo.EventID)
+	if err != nil {
+		return errors.New("event not found")
+	}
+
+	// Yetki kontrolü
+	if !canDeletePhoto(photo, event, userID) {
+		return errors.New("unauthorized")
+	}
+
+	return s.photoRepo.Restore(photo.ID)
+}
+
+// PurgeExpiredTrash saklama süresi dolan etkinlik ve fotoğrafları depolama servisi dahil kalıcı olarak siler
+func (s *TrashService) PurgeExpiredTrash() error {
+	cutoff := time.Now().Add(-s.retention)
+
+	events, err := s.eventRepo.FindDeletedBefore(cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to find deleted events: %w", err)
+	}
+
+	for _, event := range events {
+		photoCount, err := s.photoService.PurgeEventContent(&event)
+		if err != nil {
+			fmt.Printf("Error purging photos for event %d: %v\n", event.ID, err)
+			continue
+		}
+
+		if err := s.eventRepo.HardDelete(event.ID); err != nil {
+			fmt.Printf("Error purging event %d: %v\n", event.ID, err)
+			continue
+		}
+
+		fmt.Printf("Purged deleted event %d (%s) with %d photos\n", event.ID, event.Title, photoCount)
+
+		if err := s.purgeLogRepo.Create(newEventPurgeLog(&event, photoCount, models.PurgeReasonTrash)); err != nil {
+			fmt.Printf("Error recording purge log for event %d: %v\n", event.ID, err)
+		}
+	}
+
+	// Fotoğrafları gruplar halinde sil, depolama servisi hatası veren fotoğraflar bir sonraki turda tekrar denenir
+	for {
+		photos, err := s.photoRepo.FindDeletedBefore(cutoff, trashPurgeBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to find deleted photos: %w", err)
+		}
+		if len(photos) == 0 {
+			break
+		}
+
+		purged := s.photoService.PurgePhotos(photos)
+		fmt.Printf("Purged %d of %d deleted photos\n", purged, len(photos))
+
+		if purged < len(photos) || len(photos) < trashPurgeBatchSize {
+			break
+		}
+	}
+
+	return nil
+}
@@ -32,6 +32,7 @@ func NewDatabase() *gorm.DB {
 		&models.Event{},
 		&models.Photos{},
 		&models.CreditPackage{},
+		&models.EventExtensionPackage{},
 	)
 	if err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
@@ -88,6 +89,10 @@ func NewDatabase() *gorm.DB {
 		}
 	}
 
+	if err := seedEventExtensionPackages(DB); err != nil {
+		log.Fatalf("Failed to add event extension package: %v", err)
+	}
+
 	return DB
 }
 
@@ -98,6 +103,7 @@ func RunMigrations(db *gorm.DB) error {
 		&models.Event{},
 		&models.Photos{},
 		&models.CreditPackage{},
+		&models.EventExtensionPackage{},
 	)
 	if err != nil {
 		return err
@@ -154,5 +160,44 @@ func RunMigrations(db *gorm.DB) error {
 		}
 	}
 
+	return seedEventExtensionPackages(db)
+}
+
+// seedEventExtensionPackages varsayılan etkinlik süre uzatma paketlerini ekler (eğer yoksa)
+func seedEventExtensionPackages(db *gorm.DB) error {
+	packages := []models.EventExtensionPackage{
+		{
+			Name:          "+30 Days",
+			Description:   "Keep your event online for 30 more days",
+			ExtensionDays: 30,
+			Price:         4.99,
+			IsActive:      true,
+		},
+		{
+			Name:          "+3 Months",
+			Description:   "Keep your event online for 3 more months",
+			ExtensionDays: 90,
+			Price:         9.99,
+			IsActive:      true,
+		},
+		{
+			Name:        "Permanent Archive",
+			Description: "Keep your event online forever",
+			IsPermanent: true,
+			Price:       29.99,
+			IsActive:    true,
+		},
+	}
+
+	for _, pkg := range packages {
+		var count int64
+		db.Model(&models.EventExtensionPackage{}).Where("name = ?", pkg.Name).Count(&count)
+		if count == 0 {
+			if err := db.Create(&pkg).Error; err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
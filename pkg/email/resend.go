@@ -171,6 +171,245 @@ func (s *EmailService) SendVerificationEmail(email, fullName, token string) erro
 	return nil
 }
 
+// SendPhotoReportedEmail etkinlik sahibine galerisindeki bir fotoğrafın şikayet edildiğini bildirir
+func (s *EmailService) SendPhotoReportedEmail(email, fullName, eventTitle, eventURL, reason, message, thumbnailURL string, hidden bool) error {
+	s.logger.Printf("Sending photo reported email to: %s (event: %s)", email, eventURL)
+
+	templateData := map[string]interface{}{
+		"FullName":     fullName,
+		"EventTitle":   eventTitle,
+		"EventLink":    os.Getenv("FRONTEND_URL") + "/events/" + eventURL,
+		"Reason":       reason,
+		"Message":      message,
+		"ThumbnailURL": thumbnailURL,
+		"Hidden":       hidden,
+		"Email":        email,
+		"Year":         time.Now().Year(),
+	}
+
+	html, err := s.parseTemplate("templates/photo-reported.html", templateData)
+	if err != nil {
+		s.logger.Printf("Error parsing photo reported template for %s: %v", email, err)
+		return err
+	}
+
+	params := &resend.SendEmailRequest{
+		From:    s.fromName + " <" + s.from + ">",
+		To:      []string{email},
+		Subject: "A photo in your event was reported - OurPhotos",
+		Html:    html,
+	}
+
+	resp, err := s.client.Emails.Send(params)
+	if err != nil {
+		s.logger.Printf("Failed to send photo reported email to %s: %v", email, err)
+		return err
+	}
+
+	s.logger.Printf("Successfully sent photo reported email to %s (ID: %s)", email, resp.Id)
+	return nil
+}
+
+// SendEventExpiringEmail etkinlik sahibine etkinliğinin süresinin dolmak üzere olduğunu bildirir
+func (s *EmailService) SendEventExpiringEmail(email, fullName, eventTitle, eventURL string, daysLeft int, expiresAt, purgeAt time.Time) error {
+	s.logger.Printf("Sending event expiring email to: %s (event: %s, days left: %d)", email, eventURL, daysLeft)
+
+	templateData := map[string]interface{}{
+		"FullName":   fullName,
+		"EventTitle": eventTitle,
+		"EventLink":  os.Getenv("FRONTEND_URL") + "/events/" + eventURL,
+		"DaysLeft":   daysLeft,
+		"ExpiresAt":  expiresAt.Format("January 2, 2006"),
+		"PurgeAt":    purgeAt.Format("January 2, 2006"),
+		"Email":      email,
+		"Year":       time.Now().Year(),
+	}
+
+	html, err := s.parseTemplate("templates/event-expiring.html", templateData)
+	if err != nil {
+		s.logger.Printf("Error parsing event expiring template for %s: %v", email, err)
+		return err
+	}
+
+	params := &resend.SendEmailRequest{
+		From:    s.fromName + " <" + s.from + ">",
+		To:      []string{email},
+		Subject: "Your event \"" + eventTitle + "\" is expiring soon - OurPhotos",
+		Html:    html,
+	}
+
+	resp, err := s.client.Emails.Send(params)
+	if err != nil {
+		s.logger.Printf("Failed to send event expiring email to %s: %v", email, err)
+		return err
+	}
+
+	s.logger.Printf("Successfully sent event expiring email to %s (ID: %s)", email, resp.Id)
+	return nil
+}
+
+// SendEventPasswordLockoutEmail etkinlik sahibine parola denemeleri nedeniyle etkinliğin geçici olarak kilitlendiğini bildirir
+func (s *EmailService) SendEventPasswordLockoutEmail(email, fullName, eventTitle, eventURL string, failedAttempts int) error {
+	s.logger.Printf("Sending event password lockout email to: %s (event: %s)", email, eventURL)
+
+	templateData := map[string]interface{}{
+		"FullName":       fullName,
+		"EventTitle":     eventTitle,
+		"EventLink":      os.Getenv("FRONTEND_URL") + "/events/" + eventURL,
+		"FailedAttempts": failedAttempts,
+		"Email":          email,
+		"Year":           time.Now().Year(),
+	}
+
+	html, err := s.parseTemplate("templates/event-password-lockout.html", templateData)
+	if err != nil {
+		s.logger.Printf("Error parsing event password lockout template for %s: %v", email, err)
+		return err
+	}
+
+	params := &resend.SendEmailRequest{
+		From:    s.fromName + " <" + s.from + ">",
+		To:      []string{email},
+		Subject: "Repeated failed password attempts on your event - OurPhotos",
+		Html:    html,
+	}
+
+	resp, err := s.client.Emails.Send(params)
+	if err != nil {
+		s.logger.Printf("Failed to send event password lockout email to %s: %v", email, err)
+		return err
+	}
+
+	s.logger.Printf("Successfully sent event password lockout email to %s (ID: %s)", email, resp.Id)
+	return nil
+}
+
+// invitationLinks davet e-postasındaki etkinlik bağlantısını ve açılma takip pikselini oluşturur.
+// API_URL tanımlı değilse takip yapılmaz, bağlantı doğrudan etkinlik sayfasına gider.
+func invitationLinks(eventURL, inviteToken string) (string, string) {
+	apiURL := os.Getenv("API_URL")
+	if apiURL == "" {
+		return os.Getenv("FRONTEND_URL") + "/events/" + eventURL, ""
+	}
+
+	trackingURL := apiURL + "/api/invitations/" + inviteToken
+	return trackingURL, trackingURL + "/open"
+}
+
+// SendEventInvitationEmail davet listesindeki misafire etkinlik davetini gönderir.
+// accessCode boş değilse parola korumalı etkinlik için misafire özel erişim kodu e-postaya eklenir.
+func (s *EmailService) SendEventInvitationEmail(email, guestName, hostName, eventTitle, eventURL, inviteToken, accessCode string) error {
+	s.logger.Printf("Sending event invitation email to: %s (event: %s)", email, eventURL)
+
+	eventLink, trackingPixel := invitationLinks(eventURL, inviteToken)
+	templateData := map[string]interface{}{
+		"GuestName":     guestName,
+		"HostName":      hostName,
+		"EventTitle":    eventTitle,
+		"EventLink":     eventLink,
+		"TrackingPixel": trackingPixel,
+		"AccessCode":    accessCode,
+		"Email":         email,
+		"Year":          time.Now().Year(),
+	}
+
+	html, err := s.parseTemplate("templates/event-invitation.html", templateData)
+	if err != nil {
+		s.logger.Printf("Error parsing event invitation template for %s: %v", email, err)
+		return err
+	}
+
+	params := &resend.SendEmailRequest{
+		From:    s.fromName + " <" + s.from + ">",
+		To:      []string{email},
+		Subject: "You're invited to share photos at \"" + eventTitle + "\" - OurPhotos",
+		Html:    html,
+	}
+
+	resp, err := s.client.Emails.Send(params)
+	if err != nil {
+		s.logger.Printf("Failed to send event invitation email to %s: %v", email, err)
+		return err
+	}
+
+	s.logger.Printf("Successfully sent event invitation email to %s (ID: %s)", email, resp.Id)
+	return nil
+}
+
+// SendGalleryReadyEmail etkinlik sona erdikten sonra davetlilere galerinin hazır olduğunu bildirir
+func (s *EmailService) SendGalleryReadyEmail(email, guestName, eventTitle, eventURL, inviteToken string, photoCount int) error {
+	s.logger.Printf("Sending gallery ready email to: %s (event: %s)", email, eventURL)
+
+	eventLink, trackingPixel := invitationLinks(eventURL, inviteToken)
+	templateData := map[string]interface{}{
+		"GuestName":     guestName,
+		"EventTitle":    eventTitle,
+		"EventLink":     eventLink,
+		"TrackingPixel": trackingPixel,
+		"PhotoCount":    photoCount,
+		"Email":         email,
+		"Year":          time.Now().Year(),
+	}
+
+	html, err := s.parseTemplate("templates/gallery-ready.html", templateData)
+	if err != nil {
+		s.logger.Printf("Error parsing gallery ready template for %s: %v", email, err)
+		return err
+	}
+
+	params := &resend.SendEmailRequest{
+		From:    s.fromName + " <" + s.from + ">",
+		To:      []string{email},
+		Subject: "The gallery for \"" + eventTitle + "\" is ready - OurPhotos",
+		Html:    html,
+	}
+
+	resp, err := s.client.Emails.Send(params)
+	if err != nil {
+		s.logger.Printf("Failed to send gallery ready email to %s: %v", email, err)
+		return err
+	}
+
+	s.logger.Printf("Successfully sent gallery ready email to %s (ID: %s)", email, resp.Id)
+	return nil
+}
+
+// SendEventTransferEmail etkinlik sahipliği devrini kabul etme bağlantısını alıcıya gönderir
+func (s *EmailService) SendEventTransferEmail(email, senderName, eventTitle, token string, expiresAt time.Time) error {
+	s.logger.Printf("Sending event transfer email to: %s (event: %s)", email, eventTitle)
+
+	templateData := map[string]interface{}{
+		"SenderName": senderName,
+		"EventTitle": eventTitle,
+		"AcceptLink": os.Getenv("FRONTEND_URL") + "/accept-transfer?token=" + token,
+		"ExpiresAt":  expiresAt.Format("January 2, 2006"),
+		"Email":      email,
+		"Year":       time.Now().Year(),
+	}
+
+	html, err := s.parseTemplate("templates/event-transfer.html", templateData)
+	if err != nil {
+		s.logger.Printf("Error parsing event transfer template for %s: %v", email, err)
+		return err
+	}
+
+	params := &resend.SendEmailRequest{
+		From:    s.fromName + " <" + s.from + ">",
+		To:      []string{email},
+		Subject: senderName + " wants to hand over \"" + eventTitle + "\" to you - OurPhotos",
+		Html:    html,
+	}
+
+	resp, err := s.client.Emails.Send(params)
+	if err != nil {
+		s.logger.Printf("Failed to send event transfer email to %s: %v", email, err)
+		return err
+	}
+
+	s.logger.Printf("Successfully sent event transfer email to %s (ID: %s)", email, resp.Id)
+	return nil
+}
+
 func (s *EmailService) parseTemplate(templateName string, data interface{}) (string, error) {
 	s.logger.Printf("Parsing template: %s", templateName)
 
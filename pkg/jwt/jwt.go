@@ -44,3 +44,94 @@ func ValidateToken(tokenString string) (jwt.MapClaims, error) {
 
 	return nil, fmt.Errorf("invalid token")
 }
+
+// Misafir token süresi (90 gün)
+const TokenExpiryGuest = 90 * 24 * time.Hour
+
+// GenerateGuestToken belirli bir etkinlik için anonim misafir kimliği taşıyan token üretir
+func GenerateGuestToken(eventID uint, guestID string) (string, error) {
+	secretKey := []byte(os.Getenv("JWT_SECRET"))
+
+	claims := jwt.MapClaims{
+		"type":     "guest",
+		"event_id": eventID,
+		"guest_id": guestID,
+		"exp":      time.Now().Add(TokenExpiryGuest).Unix(),
+		"iat":      time.Now().Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(secretKey)
+}
+
+// ValidateGuestToken misafir tokenini doğrular ve etkinliğe ait ise misafir kimliğini döndürür
+func ValidateGuestToken(tokenString string, eventID uint) (string, error) {
+	claims, err := ValidateToken(tokenString)
+	if err != nil {
+		return "", err
+	}
+
+	if tokenType, _ := claims["type"].(string); tokenType != "guest" {
+		return "", fmt.Errorf("invalid token type")
+	}
+
+	tokenEventID, ok := claims["event_id"].(float64)
+	if !ok || uint(tokenEventID) != eventID {
+		return "", fmt.Errorf("token does not belong to this event")
+	}
+
+	guestID, ok := claims["guest_id"].(string)
+	if !ok || guestID == "" {
+		return "", fmt.Errorf("invalid guest id")
+	}
+
+	return guestID, nil
+}
+
+// Parola korumalı etkinlik erişim token süresi (24 saat)
+const TokenExpiryEventAccess = 24 * time.Hour
+
+// GenerateEventAccessToken parolası doğrulanan etkinlik için erişim tokeni üretir.
+// Token etkinliğin parola versiyonuna bağlıdır, parola değişince geçersiz olur.
+// Erişim koduyla açılan etkinliklerde codeID kodu tanımlar, etkinlik parolası için 0'dır.
+func GenerateEventAccessToken(eventID uint, passwordVersion int, codeID uint) (string, error) {
+	secretKey := []byte(os.Getenv("JWT_SECRET"))
+
+	claims := jwt.MapClaims{
+		"type":             "event_access",
+		"event_id":         eventID,
+		"password_version": passwordVersion,
+		"code_id":          codeID,
+		"exp":              time.Now().Add(TokenExpiryEventAccess).Unix(),
+		"iat":              time.Now().Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(secretKey)
+}
+
+// ValidateEventAccessToken erişim tokeninin etkinliğe ve güncel parola versiyonuna ait olduğunu
+// doğrular ve tokenin verildiği erişim kodunun ID'sini döndürür
+func ValidateEventAccessToken(tokenString string, eventID uint, passwordVersion int) (uint, error) {
+	claims, err := ValidateToken(tokenString)
+	if err != nil {
+		return 0, err
+	}
+
+	if tokenType, _ := claims["type"].(string); tokenType != "event_access" {
+		return 0, fmt.Errorf("invalid token type")
+	}
+
+	tokenEventID, ok := claims["event_id"].(float64)
+	if !ok || uint(tokenEventID) != eventID {
+		return 0, fmt.Errorf("token does not belong to this event")
+	}
+
+	tokenVersion, ok := claims["password_version"].(float64)
+	if !ok || int(tokenVersion) != passwordVersion {
+		return 0, fmt.Errorf("event password has changed")
+	}
+
+	codeID, _ := claims["code_id"].(float64)
+	return uint(codeID), nil
+}
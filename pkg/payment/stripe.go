@@ -41,8 +41,9 @@ func (s *StripeService) CreateCheckoutSession(userEmail string, priceID string,
 		CancelURL:  stripe.String(fmt.Sprintf("%s/payment/cancel", frontendURL)),
 	}
 
-	params.AddMetadata("user_id", metadata["user_id"])
-	params.AddMetadata("package_id", metadata["package_id"])
+	for key, value := range metadata {
+		params.AddMetadata(key, value)
+	}
 
 	session, err := session.New(params)
 	if err != nil {
@@ -0,0 +1,166 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Font belgeye gömülmeden kullanılan standart PDF yazı tipleri
+type Font string
+
+const (
+	FontRegular Font = "F1" // Helvetica
+	FontBold    Font = "F2" // Helvetica-Bold
+)
+
+// fontDictionary standart yazı tiplerini WinAnsi kodlamasıyla tanımlar. WinAnsi'de olmayan Türkçe
+// karakterler kullanılmayan kodlara Differences ile eşlenir.
+func fontDictionary(baseFont string) string {
+	return "<< /Type /Font /Subtype /Type1 /BaseFont /" + baseFont +
+		" /Encoding << /Type /Encoding /BaseEncoding /WinAnsiEncoding" +
+		" /Differences [127 /dotlessi 129 /Gbreve 141 /gbreve 143 /Scedilla 144 /scedilla 157 /Idotaccent] >> >>"
+}
+
+// specialCodes WinAnsi'de ASCII ve Latin-1 dışında kalan karakterlerin kodları
+var specialCodes = map[rune]byte{
+	'ı': 127, 'Ğ': 129, 'ğ': 141, 'Ş': 143, 'ş': 144, 'İ': 157,
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// Helvetica ve Helvetica-Bold karakter genişlikleri (1000 birimlik em), ASCII 32-126
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// accentBase Latin-1 harflerinin (0xC0-0xFF) genişliği için kullanılan temel harfler, '#' ise
+// genişlik fixedWidths tablosundadır
+const accentBase = "AAAAAA#CEEEEIIIIDNOOOOO#OUUUUYP#aaaaaa#ceeeeIIIIonooooo#ouuuuypy"
+
+// baseLetters WinAnsi dışındaki Türkçe harflerin genişliği için kullanılan temel harfler
+var baseLetters = map[rune]rune{'ı': 'I', 'İ': 'I', 'ğ': 'g', 'Ğ': 'G', 'ş': 's', 'Ş': 'S'}
+
+// fixedWidths harf olmayan karakterlerin genişlikleri
+var fixedWidths = map[rune]int{
+	'Æ': 1000, '×': 584, 'ß': 611, 'æ': 889, '÷': 584,
+	'€': 556, '…': 1000, '‘': 222, '’': 222, '“': 333, '”': 333,
+	'•': 350, '–': 556, '—': 1000, '™': 1000,
+}
+
+// runeWidth karakterin 1000 birimlik em cinsinden genişliğini döndürür
+func runeWidth(font Font, r rune) int {
+	widths := &helveticaWidths
+	if font == FontBold {
+		widths = &helveticaBoldWidths
+	}
+
+	if r >= 0xC0 && r <= 0xFF && accentBase[r-0xC0] != '#' {
+		r = rune(accentBase[r-0xC0])
+	} else if base, ok := baseLetters[r]; ok {
+		r = base
+	}
+
+	if r >= 32 && r <= 126 {
+		return widths[r-32]
+	}
+	if width, ok := fixedWidths[r]; ok {
+		return width
+	}
+	return 556
+}
+
+// encodeText metni yazı tipi kodlamasına dönüştürür, desteklenmeyen karakterler '?' olarak yazılır
+func encodeText(text string) []byte {
+	var buf bytes.Buffer
+	for _, r := range text {
+		var code byte
+		switch {
+		case r >= 32 && r <= 126:
+			code = byte(r)
+		case r >= 0xA0 && r <= 0xFF:
+			code = byte(r)
+		default:
+			var ok bool
+			if code, ok = specialCodes[r]; !ok {
+				code = '?'
+			}
+		}
+
+		if code == '(' || code == ')' || code == '\\' {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(code)
+	}
+	return buf.Bytes()
+}
+
+// TextWidth metnin verilen yazı tipi ve boyuttaki genişliğini punto cinsinden döndürür
+func TextWidth(font Font, size float64, text string) float64 {
+	total := 0
+	for _, r := range text {
+		total += runeWidth(font, r)
+	}
+	return float64(total) * size / 1000
+}
+
+// WrapText metni kelime sınırlarından bölerek her satırı maxWidth genişliğine sığdırır.
+// Tek başına sığmayan kelimeler karakter sınırından bölünür.
+func WrapText(font Font, size float64, text string, maxWidth float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if TextWidth(font, size, candidate) <= maxWidth {
+				line = candidate
+				continue
+			}
+
+			if line != "" {
+				lines = append(lines, line)
+			}
+
+			// Uzun kelimeyi sığan parçalara böl
+			line = ""
+			for _, r := range word {
+				if line != "" && TextWidth(font, size, line+string(r)) > maxWidth {
+					lines = append(lines, line)
+					line = ""
+				}
+				line += string(r)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Text metni sol üst köşesi (x, y) olacak şekilde tek satır olarak yazar. Renk SetFillColor ile belirlenir.
+func (p *Page) Text(font Font, size float64, x, y float64, text string) {
+	// Helvetica'nın çıkıntı yüksekliği em'in yaklaşık %72'sidir, taban çizgisi buna göre hesaplanır
+	baseline := p.Height - y - size*0.72
+	fmt.Fprintf(&p.content, "BT /%s %.2f Tf %.3f %.3f Td (%s) Tj ET\n", font, size, x, baseline, encodeText(text))
+}
+
+// CenteredText metni x ile x+width arasında yatay olarak ortalanmış şekilde yazar
+func (p *Page) CenteredText(font Font, size float64, x, y, width float64, text string) {
+	p.Text(font, size, x+(width-TextWidth(font, size, text))/2, y, text)
+}
@@ -0,0 +1,63 @@
+package pdf
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []byte
+	}{
+		{"ascii", "Hello 2024", []byte("Hello 2024")},
+		{"escapes delimiters", `(a)\b`, []byte(`\(a\)\\b`)},
+		{"latin-1", "café ü", []byte{'c', 'a', 'f', 0xE9, ' ', 0xFC}},
+		{"turkish", "ığşĞŞİ", []byte{127, 141, 144, 129, 143, 157}},
+		{"winansi punctuation", "€…–", []byte{0x80, 0x85, 0x96}},
+		{"unsupported", "日本\t", []byte("???")},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encodeText(tt.in); !bytes.Equal(got, tt.want) {
+				t.Errorf("encodeText(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	// 10 puntoda 'a' ve 'b' 5.56, boşluk 2.78 punto genişliğindedir
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth float64
+		want     []string
+	}{
+		{"fits on one line", "aaa bbb", 40, []string{"aaa bbb"}},
+		{"breaks at word", "aaa bbb", 20, []string{"aaa", "bbb"}},
+		{"collapses spaces", "aaa   bbb", 40, []string{"aaa bbb"}},
+		{"splits long word", "aaaaaaaaaa", 20, []string{"aaa", "aaa", "aaa", "a"}},
+		{"long word after short", "b aaaaaaa", 20, []string{"b", "aaa", "aaa", "a"}},
+		{"keeps paragraphs", "aaa\n\nbbb", 40, []string{"aaa", "", "bbb"}},
+		{"empty", "", 40, []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WrapText(FontRegular, 10, tt.text, tt.maxWidth)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("WrapText(%q, %v) = %q, want %q", tt.text, tt.maxWidth, got, tt.want)
+			}
+			for _, line := range got {
+				if width := TextWidth(FontRegular, 10, line); width > tt.maxWidth {
+					t.Errorf("line %q is %.2fpt wide, exceeds %.2fpt", line, width, tt.maxWidth)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Package pdf harici bağımlılık olmadan basit, vektörel PDF belgeleri üretir.
+// Koordinatlar punto (1/72 inç) cinsindendir ve sayfanın sol üst köşesinden başlar.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// Document bir veya daha fazla sayfadan oluşan PDF belgesi
+type Document struct {
+	pages  []*Page
+	images []*Image
+}
+
+// Image belgeye bir kez eklenip sayfalarda tekrar tekrar çizilebilen görsel
+type Image struct {
+	name   string
+	width  int
+	height int
+	rgb    []byte // zlib ile sıkıştırılmış RGB pikseller
+	alpha  []byte // zlib ile sıkıştırılmış saydamlık maskesi, opak görsellerde nil
+}
+
+// Page belgedeki tek bir sayfa
+type Page struct {
+	Width   float64
+	Height  float64
+	content bytes.Buffer
+}
+
+func New() *Document {
+	return &Document{}
+}
+
+// AddPage verilen boyutta (punto) yeni bir sayfa ekler
+func (d *Document) AddPage(width, height float64) *Page {
+	page := &Page{Width: width, Height: height}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// AddImage görseli belgeye ekler. Saydam pikseller için ayrı bir yumuşak maske oluşturulur.
+func (d *Document) AddImage(img image.Image) (*Image, error) {
+	bounds := img.Bounds()
+	rgb := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	alpha := make([]byte, 0, bounds.Dx()*bounds.Dy())
+	opaque := true
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			rgb = append(rgb, c.R, c.G, c.B)
+			alpha = append(alpha, c.A)
+			if c.A != 0xff {
+				opaque = false
+			}
+		}
+	}
+
+	compressedRGB, err := compress(rgb)
+	if err != nil {
+		return nil, err
+	}
+
+	pdfImage := &Image{
+		name:   fmt.Sprintf("Im%d", len(d.images)+1),
+		width:  bounds.Dx(),
+		height: bounds.Dy(),
+		rgb:    compressedRGB,
+	}
+
+	if !opaque {
+		if pdfImage.alpha, err = compress(alpha); err != nil {
+			return nil, err
+		}
+	}
+
+	d.images = append(d.images, pdfImage)
+	return pdfImage, nil
+}
+
+// SetFillColor sonraki dolgu işlemlerinin rengini belirler
+func (p *Page) SetFillColor(c color.Color) {
+	r, g, b, _ := c.RGBA()
+	fmt.Fprintf(&p.content, "%.3f %.3f %.3f rg\n", float64(r)/0xffff, float64(g)/0xffff, float64(b)/0xffff)
+}
+
+// Rect sol üst köşesi (x, y) olan dolu bir dikdörtgen çizer
+func (p *Page) Rect(x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "%.3f %.3f %.3f %.3f re f\n", x, p.Height-y-h, w, h)
+}
+
+// SetStrokeColor sonraki çizgi işlemlerinin rengini belirler
+func (p *Page) SetStrokeColor(c color.Color) {
+	r, g, b, _ := c.RGBA()
+	fmt.Fprintf(&p.content, "%.3f %.3f %.3f RG\n", float64(r)/0xffff, float64(g)/0xffff, float64(b)/0xffff)
+}
+
+// StrokeRect sol üst köşesi (x, y) olan bir dikdörtgenin kenarlarını verilen kalınlıkta çizer
+func (p *Page) StrokeRect(x, y, w, h, lineWidth float64) {
+	fmt.Fprintf(&p.content, "%.3f w %.3f %.3f %.3f %.3f re S\n", lineWidth, x, p.Height-y-h, w, h)
+}
+
+// DrawImage belgeye eklenmiş görseli sol üst köşesi (x, y) olacak şekilde verilen boyutta çizer
+func (p *Page) DrawImage(img *Image, x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "q %.3f 0 0 %.3f %.3f %.3f cm /%s Do Q\n", w, h, x, p.Height-y-h, img.name)
+}
+
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := zlib.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// objectWriter PDF nesnelerini yazar ve çapraz referans tablosu için ofsetlerini tutar
+type objectWriter struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (w *objectWriter) object(id int, dict string, stream []byte) {
+	for len(w.offsets) < id {
+		w.offsets = append(w.offsets, 0)
+	}
+	w.offsets[id-1] = w.buf.Len()
+
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\n", id, dict)
+	if stream != nil {
+		w.buf.WriteString("stream\n")
+		w.buf.Write(stream)
+		w.buf.WriteString("\nendstream\n")
+	}
+	w.buf.WriteString("endobj\n")
+}
+
+// Bytes belgeyi PDF olarak serileştirir
+func (d *Document) Bytes() ([]byte, error) {
+	if len(d.pages) == 0 {
+		return nil, fmt.Errorf("pdf document has no pages")
+	}
+
+	w := &objectWriter{}
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1: katalog, 2: sayfa ağacı, ardından görseller ve sayfalar
+	nextID := 3
+	var xObjects strings.Builder
+	for _, img := range d.images {
+		imageID := nextID
+		nextID++
+
+		smask := ""
+		if img.alpha != nil {
+			maskID := nextID
+			nextID++
+			w.object(maskID, fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>",
+				img.width, img.height, len(img.alpha)), img.alpha)
+			smask = fmt.Sprintf(" /SMask %d 0 R", maskID)
+		}
+
+		w.object(imageID, fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode%s /Length %d >>",
+			img.width, img.height, smask, len(img.rgb)), img.rgb)
+
+		fmt.Fprintf(&xObjects, " /%s %d 0 R", img.name, imageID)
+	}
+
+	// Standart yazı tipleri gömülmez, sadece tanımları eklenir
+	regularID, boldID := nextID, nextID+1
+	nextID += 2
+	w.object(regularID, fontDictionary("Helvetica"), nil)
+	w.object(boldID, fontDictionary("Helvetica-Bold"), nil)
+
+	resources := fmt.Sprintf("<< /Font << /%s %d 0 R /%s %d 0 R >>", FontRegular, regularID, FontBold, boldID)
+	if xObjects.Len() > 0 {
+		resources += " /XObject <<" + xObjects.String() + " >>"
+	}
+	resources += " >>"
+
+	var kids []string
+	for _, page := range d.pages {
+		pageID, contentID := nextID, nextID+1
+		nextID += 2
+
+		content, err := compress(page.content.Bytes())
+		if err != nil {
+			return nil, err
+		}
+
+		w.object(contentID, fmt.Sprintf("<< /Filter /FlateDecode /Length %d >>", len(content)), content)
+		w.object(pageID, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.3f %.3f] /Resources %s /Contents %d 0 R >>",
+			page.Width, page.Height, resources, contentID), nil)
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageID))
+	}
+
+	w.object(1, "<< /Type /Catalog /Pages 2 0 R >>", nil)
+	w.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)), nil)
+
+	xrefOffset := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, offset := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.offsets)+1, xrefOffset)
+
+	return w.buf.Bytes(), nil
+}
@@ -1,11 +1,74 @@
 package qrcode
 
 import (
+	"bytes"
+	"encoding/base64"
 	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strconv"
+	"strings"
 
+	"github.com/sefazor/ourphotos-backend/pkg/pdf"
 	"github.com/skip2/go-qrcode"
 )
 
+// Desteklenen çıktı formatları
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+	FormatPDF = "pdf"
+)
+
+// QR kod taramalarını işaretleyen URL parametresi, frontend bunu galeri isteğine aynen iletir
+const (
+	SourceParam = "src"
+	SourceQR    = "qr"
+)
+
+// DefaultQuietZone QR standardının önerdiği, kodun etrafındaki boşluk (modül cinsinden)
+const DefaultQuietZone = 4
+
+// logoRatio logonun kod genişliğine oranı. Yüksek hata düzeltme seviyesiyle logonun
+// kapattığı modüller okuyucu tarafından telafi edilebilir.
+const logoRatio = 0.22
+
+// Options QR kodun görünüm ayarları
+type Options struct {
+	Size       int         // PNG için piksel, SVG ve PDF için birim/punto cinsinden kenar uzunluğu
+	Foreground color.Color // Modül rengi
+	Background color.Color // Arka plan ve boşluk rengi
+	QuietZone  int         // Kodun etrafındaki boşluk, modül cinsinden
+	Logo       image.Image // Ortaya yerleştirilecek logo, nil ise logo eklenmez
+}
+
+// DefaultOptions beyaz zemin üzerinde siyah, logosuz QR kod ayarlarını döndürür
+func DefaultOptions(size int) Options {
+	return Options{
+		Size:       size,
+		Foreground: color.Black,
+		Background: color.White,
+		QuietZone:  DefaultQuietZone,
+	}
+}
+
+// ParseHexColor "#RRGGBB" veya "RRGGBB" biçimindeki rengi çözer
+func ParseHexColor(value string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) != 6 {
+		return color.RGBA{}, fmt.Errorf("invalid color: %s", value)
+	}
+
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color: %s", value)
+	}
+
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xff}, nil
+}
+
 // QRService, QR kod oluşturma ve yönetme işlemlerini sağlayan servis
 type QRService struct {
 	baseURL string // Temel URL (örn: "https://ourphotos.co/e/")
@@ -18,16 +81,296 @@ func NewQRService(baseURL string) *QRService {
 	}
 }
 
+// EventURL etkinlik URL kodunun tam adresini döndürür
+func (s *QRService) EventURL(eventURLCode string) string {
+	return fmt.Sprintf("%s%s", s.baseURL, eventURLCode)
+}
+
+// ScanURL QR koda yazılan, taramaların analitikte ayırt edilebilmesi için kaynak parametresi eklenmiş adresi döndürür
+func (s *QRService) ScanURL(eventURLCode string) string {
+	return fmt.Sprintf("%s?%s=%s", s.EventURL(eventURLCode), SourceParam, SourceQR)
+}
+
+// Code çizime hazır, boşluğu eklenmiş QR kod matrisi
+type Code struct {
+	modules [][]bool
+	opts    Options
+
+	// Aynı PDF belgesine birden fazla çizimde logo bir kez eklenir
+	pdfDoc  *pdf.Document
+	pdfLogo *pdf.Image
+}
+
+// New etkinlik URL kodu için verilen ayarlarla QR kod oluşturur.
+// Logo varsa kod en yüksek hata düzeltme seviyesiyle üretilir.
+func (s *QRService) New(eventURLCode string, opts Options) (*Code, error) {
+	if opts.Foreground == nil {
+		opts.Foreground = color.Black
+	}
+	if opts.Background == nil {
+		opts.Background = color.White
+	}
+	if opts.QuietZone < 0 {
+		opts.QuietZone = 0
+	}
+
+	level := qrcode.Medium
+	if opts.Logo != nil {
+		level = qrcode.Highest
+	}
+
+	q, err := qrcode.New(s.ScanURL(eventURLCode), level)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate QR code: %w", err)
+	}
+	q.DisableBorder = true
+
+	bitmap := q.Bitmap()
+	size := len(bitmap) + 2*opts.QuietZone
+	modules := make([][]bool, size)
+	for y := range modules {
+		modules[y] = make([]bool, size)
+	}
+	for y, row := range bitmap {
+		copy(modules[y+opts.QuietZone][opts.QuietZone:], row)
+	}
+
+	return &Code{modules: modules, opts: opts}, nil
+}
+
+// Generate etkinlik URL kodu için QR kodu istenen formatta oluşturur
+func (s *QRService) Generate(eventURLCode string, format string, opts Options) ([]byte, error) {
+	code, err := s.New(eventURLCode, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatPNG:
+		return code.PNG()
+	case FormatSVG:
+		return code.SVG()
+	case FormatPDF:
+		return code.PDF()
+	}
+	return nil, fmt.Errorf("unsupported QR code format: %s", format)
+}
+
 // GenerateQRCode, verilen etkinlik URL kodu için PNG formatında QR kod bayt dizisi oluşturur
 func (s *QRService) GenerateQRCode(eventURLCode string, size int) ([]byte, error) {
-	// Tam URL'i oluştur
-	fullURL := fmt.Sprintf("%s%s", s.baseURL, eventURLCode)
+	return s.Generate(eventURLCode, FormatPNG, DefaultOptions(size))
+}
 
-	// QR kod oluştur ve PNG olarak döndür
-	png, err := qrcode.Encode(fullURL, qrcode.Medium, size)
-	if err != nil {
+// logoBox logo alanının sol üst köşesini ve kenar uzunluğunu modül cinsinden döndürür.
+// Logo alanı etrafında bir modüllük arka plan boşluğu bırakılır.
+func (c *Code) logoBox() (float64, float64) {
+	inner := float64(len(c.modules) - 2*c.opts.QuietZone)
+	side := inner*logoRatio + 2
+	return (float64(len(c.modules)) - side) / 2, side
+}
+
+// fitLogo logoyu en-boy oranını koruyarak side x side kutunun içine sığdırır, ofset ve boyutları döndürür
+func fitLogo(logo image.Image, side float64) (float64, float64, float64, float64) {
+	bounds := logo.Bounds()
+	w, h := side, side
+	if bounds.Dx() > bounds.Dy() {
+		h = side * float64(bounds.Dy()) / float64(bounds.Dx())
+	} else if bounds.Dy() > bounds.Dx() {
+		w = side * float64(bounds.Dx()) / float64(bounds.Dy())
+	}
+	return (side - w) / 2, (side - h) / 2, w, h
+}
+
+// PNG QR kodu PNG olarak döndürür. Modüller tam piksele hizalanır, artan alan boşluğa eklenir.
+func (c *Code) PNG() ([]byte, error) {
+	count := len(c.modules)
+	size := c.opts.Size
+	scale := size / count
+	if scale < 1 {
+		scale = 1
+		size = count
+	}
+	offset := (size - scale*count) / 2
+
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c.opts.Background), image.Point{}, draw.Src)
+
+	fg := image.NewUniform(c.opts.Foreground)
+	for y, row := range c.modules {
+		for x, dark := range row {
+			if dark {
+				rect := image.Rect(offset+x*scale, offset+y*scale, offset+(x+1)*scale, offset+(y+1)*scale)
+				draw.Draw(img, rect, fg, image.Point{}, draw.Src)
+			}
+		}
+	}
+
+	if c.opts.Logo != nil {
+		start, side := c.logoBox()
+		boxX := offset + int(start*float64(scale))
+		boxSide := int(side * float64(scale))
+		draw.Draw(img, image.Rect(boxX, boxX, boxX+boxSide, boxX+boxSide), image.NewUniform(c.opts.Background), image.Point{}, draw.Src)
+
+		pad := scale
+		lx, ly, lw, lh := fitLogo(c.opts.Logo, float64(boxSide-2*pad))
+		logo := scaleImage(c.opts.Logo, int(lw), int(lh))
+		at := image.Pt(boxX+pad+int(lx), boxX+pad+int(ly))
+		draw.Draw(img, logo.Bounds().Add(at), logo, image.Point{}, draw.Over)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
 		return nil, fmt.Errorf("failed to generate QR code PNG: %w", err)
 	}
+	return buf.Bytes(), nil
+}
+
+// SVG QR kodu ölçeklenebilir vektör grafik olarak döndürür. Koordinatlar modül cinsindendir.
+func (c *Code) SVG() ([]byte, error) {
+	count := len(c.modules)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		c.opts.Size, c.opts.Size, count, count)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="%s"/>`, count, count, hexColor(c.opts.Background))
+
+	// Yan yana koyu modüller tek bir dikdörtgen olarak yazılır
+	fmt.Fprintf(&buf, `<path fill="%s" d="`, hexColor(c.opts.Foreground))
+	c.eachRun(func(x, y, run int) {
+		fmt.Fprintf(&buf, "M%d %dh%dv1h-%dz", x, y, run, run)
+	})
+	buf.WriteString(`"/>`)
+
+	if c.opts.Logo != nil {
+		start, side := c.logoBox()
+		fmt.Fprintf(&buf, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s"/>`, start, start, side, side, hexColor(c.opts.Background))
+
+		// Logo SVG içine gömülü PNG olarak eklenir, boyutu makul bir çözünürlükle sınırlanır
+		lx, ly, lw, lh := fitLogo(c.opts.Logo, side-2)
+		logo := c.opts.Logo
+		if bounds := logo.Bounds(); bounds.Dx() > 512 || bounds.Dy() > 512 {
+			_, _, pw, ph := fitLogo(logo, 512)
+			logo = scaleImage(logo, int(pw), int(ph))
+		}
+
+		var logoPNG bytes.Buffer
+		if err := png.Encode(&logoPNG, logo); err != nil {
+			return nil, fmt.Errorf("failed to encode QR code logo: %w", err)
+		}
+		fmt.Fprintf(&buf, `<image x="%.2f" y="%.2f" width="%.2f" height="%.2f" href="data:image/png;base64,%s"/>`,
+			start+1+lx, start+1+ly, lw, lh, base64.StdEncoding.EncodeToString(logoPNG.Bytes()))
+	}
+
+	buf.WriteString(`</svg>`)
+	return buf.Bytes(), nil
+}
+
+// PDF QR kodu tek sayfalık vektörel PDF olarak döndürür. Sayfa boyutu punto cinsinden Size'dır.
+func (c *Code) PDF() ([]byte, error) {
+	doc := pdf.New()
+	size := float64(c.opts.Size)
+	page := doc.AddPage(size, size)
+
+	if err := c.DrawPDF(doc, page, 0, 0, size); err != nil {
+		return nil, err
+	}
+	return doc.Bytes()
+}
+
+// DrawPDF QR kodu sayfaya sol üst köşesi (x, y) ve kenar uzunluğu size olacak şekilde vektörel olarak çizer
+func (c *Code) DrawPDF(doc *pdf.Document, page *pdf.Page, x, y, size float64) error {
+	module := size / float64(len(c.modules))
+
+	page.SetFillColor(c.opts.Background)
+	page.Rect(x, y, size, size)
+
+	page.SetFillColor(c.opts.Foreground)
+	c.eachRun(func(mx, my, run int) {
+		page.Rect(x+float64(mx)*module, y+float64(my)*module, float64(run)*module, module)
+	})
+
+	if c.opts.Logo == nil {
+		return nil
+	}
+
+	if c.pdfDoc != doc {
+		logo, err := doc.AddImage(c.opts.Logo)
+		if err != nil {
+			return fmt.Errorf("failed to embed QR code logo: %w", err)
+		}
+		c.pdfDoc, c.pdfLogo = doc, logo
+	}
+
+	start, side := c.logoBox()
+	page.SetFillColor(c.opts.Background)
+	page.Rect(x+start*module, y+start*module, side*module, side*module)
+
+	lx, ly, lw, lh := fitLogo(c.opts.Logo, (side-2)*module)
+	page.DrawImage(c.pdfLogo, x+(start+1)*module+lx, y+(start+1)*module+ly, lw, lh)
+	return nil
+}
+
+// eachRun her satırdaki ardışık koyu modül dizilerini (x, y, uzunluk) olarak dolaşır
+func (c *Code) eachRun(fn func(x, y, run int)) {
+	for y, row := range c.modules {
+		for x := 0; x < len(row); {
+			if !row[x] {
+				x++
+				continue
+			}
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fn(start, y, x-start)
+		}
+	}
+}
+
+func hexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+// scaleImage görseli her hedef pikselin kapladığı kaynak piksellerin ortalamasını alarak yeniden boyutlandırır
+func scaleImage(src image.Image, width, height int) *image.NRGBA {
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := bounds.Min.Y + (y+1)*bounds.Dy()/height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := bounds.Min.X + (x+1)*bounds.Dx()/width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			// Önceden çarpılmış ortalamayı NRGBA'ya dönüştür
+			rgba := color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)}
+			dst.Set(x, y, rgba)
+		}
+	}
 
-	return png, nil
+	return dst
 }
@@ -0,0 +1,94 @@
+package realtime
+
+import (
+	"fmt"
+	"sync"
+)
+
+// subscriberBuffer her abonenin kanalında bekletilebilecek mesaj sayısı.
+// Yavaş abonelere gönderilemeyen mesajlar atlanır, yayıncı bloklanmaz.
+const subscriberBuffer = 16
+
+// Notifier mesajları diğer sunucu örneklerine dağıtan arka uç (örn: Postgres NOTIFY)
+type Notifier interface {
+	Notify(topic uint, payload []byte) error
+}
+
+// Broker etkinlik bazlı (topic = event ID) process içi pub/sub sağlar
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[uint]map[chan []byte]struct{}
+	notifier    Notifier
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		subscribers: make(map[uint]map[chan []byte]struct{}),
+	}
+}
+
+// SetNotifier mesajların tüm sunuculara dağıtılması için bir arka uç ayarlar.
+// Notifier ayarlıysa mesajlar yerel abonelere Dispatch üzerinden geri döner.
+func (b *Broker) SetNotifier(notifier Notifier) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.notifier = notifier
+}
+
+// Subscribe topic'e abone olur, mesaj kanalını ve aboneliği sonlandıran fonksiyonu döndürür
+func (b *Broker) Subscribe(topic uint) (<-chan []byte, func()) {
+	ch := make(chan []byte, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[chan []byte]struct{})
+	}
+	b.subscribers[topic][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[topic], ch)
+			if len(b.subscribers[topic]) == 0 {
+				delete(b.subscribers, topic)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish mesajı yayınlar. Notifier varsa tüm sunuculara, yoksa sadece yerel abonelere gider.
+func (b *Broker) Publish(topic uint, payload []byte) {
+	b.mu.RLock()
+	notifier := b.notifier
+	b.mu.RUnlock()
+
+	if notifier != nil {
+		err := notifier.Notify(topic, payload)
+		if err == nil {
+			return
+		}
+		fmt.Printf("Realtime notify failed, falling back to local dispatch: %v\n", err)
+	}
+
+	b.Dispatch(topic, payload)
+}
+
+// Dispatch mesajı bu sunucudaki abonelere iletir
+func (b *Broker) Dispatch(topic uint, payload []byte) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[topic] {
+		select {
+		case ch <- payload:
+		default:
+			// Abone yetişemiyor, mesajı atla
+		}
+	}
+}
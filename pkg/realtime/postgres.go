@@ -0,0 +1,90 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+// PostgresFanout mesajları Postgres LISTEN/NOTIFY ile tüm sunucu örneklerine dağıtır
+type PostgresFanout struct {
+	db          *gorm.DB
+	databaseURL string
+	channel     string
+}
+
+type fanoutEnvelope struct {
+	Topic   uint            `json:"topic"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+func NewPostgresFanout(db *gorm.DB, databaseURL string, channel string) *PostgresFanout {
+	return &PostgresFanout{
+		db:          db,
+		databaseURL: databaseURL,
+		channel:     channel,
+	}
+}
+
+// Notify mesajı pg_notify ile yayınlar
+func (f *PostgresFanout) Notify(topic uint, payload []byte) error {
+	envelope, err := json.Marshal(fanoutEnvelope{Topic: topic, Payload: payload})
+	if err != nil {
+		return err
+	}
+	return f.db.Exec("SELECT pg_notify(?, ?)", f.channel, string(envelope)).Error
+}
+
+// Listen kanalı dinler ve gelen mesajları handler'a iletir. Bağlantı koparsa
+// artan bekleme süreleriyle yeniden bağlanır, context iptal edilene kadar döner.
+func (f *PostgresFanout) Listen(ctx context.Context, handler func(topic uint, payload []byte)) {
+	backoff := time.Second
+	for {
+		err := f.listen(ctx, handler)
+		if ctx.Err() != nil {
+			return
+		}
+
+		fmt.Printf("Realtime listener disconnected: %v, retrying in %s\n", err, backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func (f *PostgresFanout) listen(ctx context.Context, handler func(topic uint, payload []byte)) error {
+	conn, err := pgx.Connect(ctx, f.databaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{f.channel}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var envelope fanoutEnvelope
+		if err := json.Unmarshal([]byte(notification.Payload), &envelope); err != nil {
+			fmt.Printf("Realtime listener received invalid payload: %v\n", err)
+			continue
+		}
+
+		handler(envelope.Topic, envelope.Payload)
+	}
+}
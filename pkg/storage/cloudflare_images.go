@@ -198,6 +198,29 @@ func (c *CloudflareImages) Delete(imageID string) error {
 	return nil
 }
 
+// Download görselin küçük boyutlu sürümünü indirir. Sunucu tarafında çözülebilmesi için
+// WebP/AVIF yerine PNG veya JPEG istenir.
+func (c *CloudflareImages) Download(imageID string) ([]byte, error) {
+	req, err := http.NewRequest("GET", c.GetThumbnailURL(imageID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "image/png,image/jpeg")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download image: %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+}
+
 func (c *CloudflareImages) GetPublicURL(imageID string) string {
 	return fmt.Sprintf("https://imagedelivery.net/%s/%s/%s", c.accountHash, imageID, VariantPublic)
 }